@@ -2,14 +2,24 @@ package main
 
 import (
 	"context"
+	"errors"
 	"flag"
+	"fmt"
 	"log"
+	"os"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/plugin"
 	"github.com/tozny/terraform-provider-tozny/tozny"
 )
 
 func main() {
+	// Generate configuration for an existing realm if the generate subcommand is specified
+	if len(os.Args) > 1 && os.Args[1] == "generate" {
+		if err := generate(os.Args[2:]); err != nil {
+			log.Fatal(err)
+		}
+		return
+	}
 	// Parse command line debug flag if present
 	var debugMode bool
 	flag.BoolVar(&debugMode, "debuggable", false, "set to true to run the provider with support for debuggers like delve")
@@ -30,3 +40,34 @@ func main() {
 			ProviderFunc: tozny.Provider})
 	}
 }
+
+// generate writes Terraform configuration and import blocks for the objects of an existing realm,
+// printing the paths of the written files and returning error (if any).
+func generate(args []string) error {
+	var credentialsFilepath, realmName, outputDir string
+	generateFlags := flag.NewFlagSet("generate", flag.ExitOnError)
+	generateFlags.StringVar(&credentialsFilepath, "credentials", os.Getenv("TOZNY_CLIENT_CREDENTIALS_FILEPATH"), "filepath to Tozny client credentials with permissions to manage the realm, defaults to TOZNY_CLIENT_CREDENTIALS_FILEPATH")
+	generateFlags.StringVar(&realmName, "realm", "", "name of the realm to generate configuration for")
+	generateFlags.StringVar(&outputDir, "out", ".", "directory to write the generated configuration to, which must not already contain generated files")
+	generateFlags.Parse(args)
+
+	if credentialsFilepath == "" || realmName == "" {
+		generateFlags.Usage()
+		return errors.New("both -credentials and -realm must be specified")
+	}
+
+	toznySDK, err := tozny.MakeToznySDKFromCredentials(credentialsFilepath, "", tozny.TerraformToznySDKResult{})
+	if err != nil {
+		return err
+	}
+
+	writtenFiles, skipped, err := tozny.GenerateRealmConfiguration(context.Background(), toznySDK, realmName, outputDir)
+	for _, writtenFile := range writtenFiles {
+		fmt.Println(writtenFile)
+	}
+	for _, skippedObject := range skipped {
+		fmt.Fprintf(os.Stderr, "warning: skipped %s, declare it by hand\n", skippedObject)
+	}
+
+	return err
+}
@@ -0,0 +1,80 @@
+package tozny
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// dataSourceAccount returns the schema and methods for describing the Tozny account and client the provider is configured with
+func dataSourceAccount() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceAccountRead,
+		Schema: map[string]*schema.Schema{
+			"account_id": {
+				Description: "Service defined unique identifier for the account. Only populated when the provider is configured with account credentials.",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+			"account_username": {
+				Description: "The username of the account the provider is configured with.",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+			"client_id": {
+				Description: "The server defined unique identifier for the Tozny client the provider is configured with.",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+			"api_endpoint": {
+				Description: "Network location the provider uses for API management and provisioning of Tozny products & services.",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+			"public_key": {
+				Description: "The public key of the keypair used for client level encryption operations.",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+			"public_signing_key": {
+				Description: "The public key of the keypair used for client level signing operations.",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+		},
+	}
+}
+
+func dataSourceAccountRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	terraformToznySDKResult := m.(TerraformToznySDKResult)
+	toznySDK := terraformToznySDKResult.SDK
+
+	if terraformToznySDKResult.Err != nil {
+		return diag.FromErr(terraformToznySDKResult.Err)
+	}
+
+	clientID := toznySDK.E3dbPDSClient.ClientID
+
+	d.Set("account_username", toznySDK.AccountUsername)
+	d.Set("client_id", clientID)
+	d.Set("api_endpoint", toznySDK.APIEndpoint)
+	d.Set("public_key", toznySDK.E3dbPDSClient.EncryptionKeys.Public.Material)
+	d.Set("public_signing_key", toznySDK.E3dbPDSClient.SigningKeys.Public.Material)
+
+	// Account level details are only available with account credentials
+	if toznySDK.AccountUsername != "" && toznySDK.AccountPassword != "" {
+		account, err := toznySDK.Login(ctx, toznySDK.AccountUsername, toznySDK.AccountPassword, "password", toznySDK.APIEndpoint)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+
+		d.Set("account_id", account.AccountID)
+	}
+
+	d.SetId(clientID)
+
+	return diags
+}
@@ -0,0 +1,192 @@
+package tozny
+
+import (
+	"context"
+	"net/http"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/tozny/e3db-clients-go/accountClient"
+)
+
+// registrationTokenListing wraps a registration token as listed by the account service's token list,
+// the endpoint the Tozny SDK's ListRegistrationTokens reads, including when the token was created
+// which the SDK's registration tokens drop.
+type registrationTokenListing struct {
+	Token            string                         `json:"token"`
+	Name             string                         `json:"name"`
+	Permissions      accountClient.TokenPermissions `json:"permissions"`
+	TotalUsesAllowed int                            `json:"total_uses_allowed"`
+	Uses             int                            `json:"uses"`
+	CreatedAt        string                         `json:"created_at"`
+}
+
+// dataSourceClientRegistrationTokens returns the schema and methods for listing the Client Registration Tokens of a Tozny account
+func dataSourceClientRegistrationTokens() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceClientRegistrationTokensRead,
+		Schema: map[string]*schema.Schema{
+			"client_credentials_filepath": {
+				Description:   "The filepath to Tozny client credentials for the provider to use when listing registration tokens.",
+				Type:          schema.TypeString,
+				Optional:      true,
+				Default:       "",
+				ForceNew:      true,
+				ConflictsWith: []string{"client_credentials_config"},
+			},
+			"client_credentials_config": {
+				Description:   "The Tozny account client configuration as a JSON string",
+				Type:          schema.TypeString,
+				Optional:      true,
+				Default:       "",
+				ForceNew:      true,
+				Sensitive:     true,
+				ConflictsWith: []string{"client_credentials_filepath"},
+			},
+			"name_prefix": {
+				Description: "Only list tokens whose name begins with this prefix.",
+				Type:        schema.TypeString,
+				Optional:    true,
+				Default:     "",
+			},
+			"allowed_registration_client_type": {
+				Description: "Only list tokens that can be used to register clients of this type. Valid types are `general`, `identity`, and `broker`.",
+				Type:        schema.TypeString,
+				Optional:    true,
+				Default:     "",
+			},
+			"include_token_values": {
+				Description: "Whether to populate the value of each listed token. Defaults to false.",
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+			},
+			"tokens": {
+				Description: "The registration tokens matching the specified filters.",
+				Type:        schema.TypeList,
+				Computed:    true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"name": {
+							Description: "User defined identifier for the token.",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+						"enabled": {
+							Description: "Whether the clients can be registered using this token.",
+							Type:        schema.TypeBool,
+							Computed:    true,
+						},
+						"one_time_use": {
+							Description: "Whether the token is only valid for registering a single client.",
+							Type:        schema.TypeBool,
+							Computed:    true,
+						},
+						"allowed_registration_client_types": {
+							Description: "The client types that can be registered using the token.",
+							Type:        schema.TypeList,
+							Computed:    true,
+							Elem: &schema.Schema{
+								Type: schema.TypeString,
+							},
+						},
+						"max_uses": {
+							Description: "The maximum number of clients that can be registered using the token, `0` if there is no limit.",
+							Type:        schema.TypeInt,
+							Computed:    true,
+						},
+						"uses": {
+							Description: "The number of clients that have been registered using the token.",
+							Type:        schema.TypeInt,
+							Computed:    true,
+						},
+						"uses_remaining": {
+							Description: "The number of clients that can still be registered using the token, or `-1` if the token has no limit on uses.",
+							Type:        schema.TypeInt,
+							Computed:    true,
+						},
+						"created_at": {
+							Description: "When the token was created.",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+						"token": {
+							Description: "Client registration token. Only populated when `include_token_values` is true.",
+							Type:        schema.TypeString,
+							Computed:    true,
+							Sensitive:   true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceClientRegistrationTokensRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	toznySDK, account, err := MakeToznySession(ctx, d, m)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	var listedRegistrationTokens []registrationTokenListing
+
+	err = makeAccountServiceCall(ctx, toznySDK.APIEndpoint, account.Token, http.MethodGet, "/v1/account/tokens", nil, &listedRegistrationTokens)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	namePrefix := d.Get("name_prefix").(string)
+	clientType := d.Get("allowed_registration_client_type").(string)
+	includeTokenValues := d.Get("include_token_values").(bool)
+
+	tokens := []interface{}{}
+
+	for _, listedRegistrationToken := range listedRegistrationTokens {
+		if !strings.HasPrefix(listedRegistrationToken.Name, namePrefix) {
+			continue
+		}
+
+		if clientType != "" {
+			var allowed bool
+			for _, allowedType := range listedRegistrationToken.Permissions.AllowedTypes {
+				if allowedType == clientType {
+					allowed = true
+					break
+				}
+			}
+			if !allowed {
+				continue
+			}
+		}
+
+		var tokenValue string
+		if includeTokenValues {
+			tokenValue = listedRegistrationToken.Token
+		}
+
+		tokens = append(tokens, map[string]interface{}{
+			"name":                              listedRegistrationToken.Name,
+			"enabled":                           listedRegistrationToken.Permissions.Enabled,
+			"one_time_use":                      listedRegistrationToken.Permissions.OneTime,
+			"allowed_registration_client_types": listedRegistrationToken.Permissions.AllowedTypes,
+			"max_uses":                          listedRegistrationToken.TotalUsesAllowed,
+			"uses":                              listedRegistrationToken.Uses,
+			"uses_remaining":                    registrationTokenUsesRemaining(listedRegistrationToken.Permissions.OneTime, listedRegistrationToken.TotalUsesAllowed, listedRegistrationToken.Uses),
+			"created_at":                        listedRegistrationToken.CreatedAt,
+			"token":                             tokenValue,
+		})
+	}
+
+	if err := d.Set("tokens", tokens); err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(uuid.New().String())
+
+	return diags
+}
@@ -0,0 +1,213 @@
+package tozny
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// dataSourceRealm returns the schema and methods for gathering data about an existing Tozny Realm
+func dataSourceRealm() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceRealmRead,
+		Schema: map[string]*schema.Schema{
+			"client_credentials_filepath": {
+				Description:   "The filepath to Tozny client credentials for the provider to use when reading this realm.",
+				Type:          schema.TypeString,
+				Optional:      true,
+				Default:       "",
+				ForceNew:      true,
+				ConflictsWith: []string{"client_credentials_config"},
+			},
+			"client_credentials_config": {
+				Description:   "The Tozny account client configuration as a JSON string",
+				Type:          schema.TypeString,
+				Optional:      true,
+				Default:       "",
+				ForceNew:      true,
+				Sensitive:     true,
+				ConflictsWith: []string{"client_credentials_filepath"},
+			},
+			"realm_name": {
+				Description: "User defined identifier for the realm.",
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+			},
+			"realm_id": {
+				Description: "Service defined unique identifier for the realm.",
+				Type:        schema.TypeInt,
+				Computed:    true,
+			},
+			"domain": {
+				Description: "Service defined & externally unique reference for the realm.",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+			"admin_url": {
+				Description: "URL for realm administration console.",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+			"active": {
+				Description: "Whether the realm is active for applications and identities to consume.",
+				Type:        schema.TypeBool,
+				Computed:    true,
+			},
+			"broker_identity_tozny_id": {
+				Description: "The Tozny Client ID associated with the Identity used to broker interactions between the realm and it's Identities. Will be empty if no realm broker Identity has been registered.",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+			"sovereign": {
+				Description: "The admin identity for a realm.",
+				Type:        schema.TypeList,
+				Computed:    true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"id": {
+							Description: "Service defined unique identifier for the sovereign.",
+							Type:        schema.TypeInt,
+							Computed:    true,
+						},
+						"name": {
+							Description: "User defined sovereign identifier.",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+					},
+				},
+			},
+			"mpc_enabled": {
+				Description: "Whether MPC is enabled for the Realm.",
+				Type:        schema.TypeBool,
+				Computed:    true,
+			},
+			"secrets_enabled": {
+				Description: "Whether TozSecrets is enabled for the Realm.",
+				Type:        schema.TypeBool,
+				Computed:    true,
+			},
+			"tozid_federation_enabled": {
+				Description: "Whether TozID Federation is enabled for the Realm.",
+				Type:        schema.TypeBool,
+				Computed:    true,
+			},
+			"forgot_password_custom_link": {
+				Description: "Link to custom forgot password page.",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+			"forgot_password_custom_text": {
+				Description: "Text which will be used as a guidance for the offline password recovery flow.",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+			"sso_session_idle_timeout": {
+				Description: "Seconds an SSO session can be idle before it expires.",
+				Type:        schema.TypeInt,
+				Computed:    true,
+			},
+			"sso_session_max_lifespan": {
+				Description: "Maximum seconds an SSO session can last before it expires.",
+				Type:        schema.TypeInt,
+				Computed:    true,
+			},
+			"access_token_lifespan": {
+				Description: "Seconds before an access token expires.",
+				Type:        schema.TypeInt,
+				Computed:    true,
+			},
+			"revoke_refresh_token": {
+				Description: "Whether refresh tokens are revoked after being used more than `refresh_token_max_reuse` times.",
+				Type:        schema.TypeBool,
+				Computed:    true,
+			},
+			"refresh_token_max_reuse": {
+				Description: "Number of times a refresh token can be reused when `revoke_refresh_token` is enabled.",
+				Type:        schema.TypeInt,
+				Computed:    true,
+			},
+			"offline_session_idle_timeout": {
+				Description: "Seconds an offline session can be idle before it expires.",
+				Type:        schema.TypeInt,
+				Computed:    true,
+			},
+			"offline_session_max_lifespan_enabled": {
+				Description: "Whether offline sessions have a maximum lifespan.",
+				Type:        schema.TypeBool,
+				Computed:    true,
+			},
+			"offline_session_max_lifespan": {
+				Description: "Maximum seconds an offline session can last when `offline_session_max_lifespan_enabled` is true.",
+				Type:        schema.TypeInt,
+				Computed:    true,
+			},
+			"login_timeout": {
+				Description: "Seconds an identity has to complete a login.",
+				Type:        schema.TypeInt,
+				Computed:    true,
+			},
+			"login_action_timeout": {
+				Description: "Seconds an identity has to complete login related actions, such as updating their password.",
+				Type:        schema.TypeInt,
+				Computed:    true,
+			},
+			"registration_allowed": {
+				Description: "Whether identities can register themselves with the realm.",
+				Type:        schema.TypeBool,
+				Computed:    true,
+			},
+			"registration_email_as_username": {
+				Description: "Whether identities registering with the realm use their email as their username.",
+				Type:        schema.TypeBool,
+				Computed:    true,
+			},
+			"verify_email": {
+				Description: "Whether identities are required to verify their email address.",
+				Type:        schema.TypeBool,
+				Computed:    true,
+			},
+			"login_with_email_allowed": {
+				Description: "Whether identities can log in with their email address as well as their username.",
+				Type:        schema.TypeBool,
+				Computed:    true,
+			},
+			"duplicate_emails_allowed": {
+				Description: "Whether several identities can have the same email address.",
+				Type:        schema.TypeBool,
+				Computed:    true,
+			},
+			"remember_me": {
+				Description: "Whether the login page offers identities to remember them between browser restarts.",
+				Type:        schema.TypeBool,
+				Computed:    true,
+			},
+			"reset_password_allowed": {
+				Description: "Whether the login page offers identities to reset a forgotten password.",
+				Type:        schema.TypeBool,
+				Computed:    true,
+			},
+		},
+	}
+}
+
+func dataSourceRealmRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	toznySDK, err := MakeToznySDK(d, m)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	err = readRealmIntoState(ctx, toznySDK, d.Get("realm_name").(string), d)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(fmt.Sprintf("%d", d.Get("realm_id").(int)))
+
+	return diags
+}
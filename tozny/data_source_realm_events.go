@@ -0,0 +1,320 @@
+package tozny
+
+import (
+	"context"
+	"net/url"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+// dataSourceRealmEvents returns the schema and methods for querying the login and admin events saved by a Tozny Realm
+func dataSourceRealmEvents() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceRealmEventsRead,
+		Schema: map[string]*schema.Schema{
+			"client_credentials_filepath": {
+				Description:   "The filepath to Tozny client credentials for the provider to use when querying events.",
+				Type:          schema.TypeString,
+				Optional:      true,
+				Default:       "",
+				ForceNew:      true,
+				ConflictsWith: []string{"client_credentials_config"},
+			},
+			"client_credentials_config": {
+				Description:   "The Tozny account client configuration as a JSON string",
+				Type:          schema.TypeString,
+				Optional:      true,
+				Default:       "",
+				ForceNew:      true,
+				Sensitive:     true,
+				ConflictsWith: []string{"client_credentials_filepath"},
+			},
+			"realm_name": {
+				Description: "Name of the realm to query events of.",
+				Type:        schema.TypeString,
+				Required:    true,
+			},
+			"types": {
+				Description: "Only include login events of these types, e.g. `LOGIN_ERROR`.",
+				Type:        schema.TypeList,
+				Optional:    true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"operation_types": {
+				Description: "Only include admin events for these operations, e.g. `CREATE` or `DELETE`.",
+				Type:        schema.TypeList,
+				Optional:    true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"identity_id": {
+				Description: "Only include events for (or admin events performed by) the identity with this ID.",
+				Type:        schema.TypeString,
+				Optional:    true,
+				Default:     "",
+			},
+			"client_id": {
+				Description: "Only include events for (or admin events performed by) the application with this client ID.",
+				Type:        schema.TypeString,
+				Optional:    true,
+				Default:     "",
+			},
+			"ip_address": {
+				Description: "Only include events that originated from this IP address.",
+				Type:        schema.TypeString,
+				Optional:    true,
+				Default:     "",
+			},
+			"from": {
+				Description:  "Only include events at or after this RFC 3339 timestamp.",
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      "",
+				ValidateFunc: validation.IsRFC3339Time,
+			},
+			"to": {
+				Description:  "Only include events at or before this RFC 3339 timestamp.",
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      "",
+				ValidateFunc: validation.IsRFC3339Time,
+			},
+			"include_login_events": {
+				Description: "Whether to query login events.",
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     true,
+			},
+			"include_admin_events": {
+				Description: "Whether to query admin events.",
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+			},
+			"max_results": {
+				Description:  "Maximum number of login events and of admin events to return. `0` to return all matching events.",
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      1000,
+				ValidateFunc: validation.IntAtLeast(0),
+			},
+			"events": {
+				Description: "The login events matching the specified filters.",
+				Type:        schema.TypeList,
+				Computed:    true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"time": {
+							Description: "When the event happened as an RFC 3339 timestamp.",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+						"type": {
+							Description: "Type of the event.",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+						"identity_id": {
+							Description: "ID of the identity the event is for.",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+						"client_id": {
+							Description: "Client ID of the application the event is for.",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+						"session_id": {
+							Description: "ID of the session the event happened in.",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+						"ip_address": {
+							Description: "IP address the event originated from.",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+						"error": {
+							Description: "Error of the event, empty if the event was successful.",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+						"details": {
+							Description: "Additional details of the event.",
+							Type:        schema.TypeMap,
+							Computed:    true,
+							Elem: &schema.Schema{
+								Type: schema.TypeString,
+							},
+						},
+					},
+				},
+			},
+			"admin_events": {
+				Description: "The admin events matching the specified filters.",
+				Type:        schema.TypeList,
+				Computed:    true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"time": {
+							Description: "When the event happened as an RFC 3339 timestamp.",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+						"operation_type": {
+							Description: "Operation performed.",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+						"resource_type": {
+							Description: "Type of the resource the operation was performed on.",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+						"resource_path": {
+							Description: "Path of the resource the operation was performed on.",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+						"auth_identity_id": {
+							Description: "ID of the identity that performed the operation.",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+						"auth_client_id": {
+							Description: "Client ID of the application used to perform the operation.",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+						"auth_ip_address": {
+							Description: "IP address the operation originated from.",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+						"error": {
+							Description: "Error of the operation, empty if the operation was successful.",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+						"representation": {
+							Description: "JSON representation of the request, if the realm saves admin event representations.",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceRealmEventsRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	toznySDK, err := MakeToznySDK(d, m)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	realmName := d.Get("realm_name").(string)
+	maxResults := d.Get("max_results").(int)
+
+	window, err := newRealmEventsWindow(d.Get("from").(string), d.Get("to").(string))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	events := []interface{}{}
+	if d.Get("include_login_events").(bool) {
+		query := window.query()
+		for _, eventType := range SchemaToStringSlice(d.Get("types").([]interface{})) {
+			query.Add("type", eventType)
+		}
+		setRealmEventsQueryFilters(d, query, "user", "client", "ip_address")
+
+		listedEvents, err := listRealmEvents(ctx, toznySDK, realmName, window, query, maxResults)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+
+		for _, event := range listedEvents {
+			events = append(events, map[string]interface{}{
+				"time":        formatRealmEventTime(event.Time),
+				"type":        event.Type,
+				"identity_id": event.UserID,
+				"client_id":   event.ClientID,
+				"session_id":  event.SessionID,
+				"ip_address":  event.IPAddress,
+				"error":       event.Error,
+				"details":     event.Details,
+			})
+		}
+	}
+
+	adminEvents := []interface{}{}
+	if d.Get("include_admin_events").(bool) {
+		query := window.query()
+		for _, operationType := range SchemaToStringSlice(d.Get("operation_types").([]interface{})) {
+			query.Add("operation_type", operationType)
+		}
+		setRealmEventsQueryFilters(d, query, "auth_user", "auth_client", "auth_ip_address")
+
+		listedAdminEvents, err := listRealmAdminEvents(ctx, toznySDK, realmName, window, query, maxResults)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+
+		for _, adminEvent := range listedAdminEvents {
+			adminEvents = append(adminEvents, map[string]interface{}{
+				"time":             formatRealmEventTime(adminEvent.Time),
+				"operation_type":   adminEvent.OperationType,
+				"resource_type":    adminEvent.ResourceType,
+				"resource_path":    adminEvent.ResourcePath,
+				"auth_identity_id": adminEvent.AuthDetails.UserID,
+				"auth_client_id":   adminEvent.AuthDetails.ClientID,
+				"auth_ip_address":  adminEvent.AuthDetails.IPAddress,
+				"error":            adminEvent.Error,
+				"representation":   adminEvent.Representation,
+			})
+		}
+	}
+
+	if err := d.Set("events", events); err != nil {
+		return diag.FromErr(err)
+	}
+
+	if err := d.Set("admin_events", adminEvents); err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(uuid.New().String())
+
+	return diags
+}
+
+// setRealmEventsQueryFilters sets the identity, client and IP address filters (if any) on an events query
+// using the query parameter names for the kind of events being queried.
+func setRealmEventsQueryFilters(d *schema.ResourceData, query url.Values, identityParameter string, clientParameter string, ipAddressParameter string) {
+	filters := map[string]string{
+		identityParameter:  d.Get("identity_id").(string),
+		clientParameter:    d.Get("client_id").(string),
+		ipAddressParameter: d.Get("ip_address").(string),
+	}
+	for parameter, value := range filters {
+		if value != "" {
+			query.Set(parameter, value)
+		}
+	}
+}
+
+// formatRealmEventTime formats the time of a realm event, in milliseconds since the epoch, as an RFC 3339 timestamp.
+func formatRealmEventTime(milliseconds int64) string {
+	return time.Unix(0, milliseconds*int64(time.Millisecond)).UTC().Format(time.RFC3339)
+}
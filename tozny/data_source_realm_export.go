@@ -0,0 +1,125 @@
+package tozny
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+// dataSourceRealmExport returns the schema and methods for exporting the configuration of a Tozny Realm as a single JSON document
+func dataSourceRealmExport() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceRealmExportRead,
+		Schema: map[string]*schema.Schema{
+			"client_credentials_filepath": {
+				Description:   "The filepath to Tozny client credentials for the provider to use when exporting the realm.",
+				Type:          schema.TypeString,
+				Optional:      true,
+				Default:       "",
+				ForceNew:      true,
+				ConflictsWith: []string{"client_credentials_config"},
+			},
+			"client_credentials_config": {
+				Description:   "The Tozny account client configuration as a JSON string",
+				Type:          schema.TypeString,
+				Optional:      true,
+				Default:       "",
+				ForceNew:      true,
+				Sensitive:     true,
+				ConflictsWith: []string{"client_credentials_filepath"},
+			},
+			"realm_name": {
+				Description: "Name of the realm to export.",
+				Type:        schema.TypeString,
+				Required:    true,
+			},
+			"secrets": {
+				Description:  "How secrets such as LDAP bind credentials and identity provider client secrets are exported, `omit` to leave them out of the document or `encrypt` to encrypt them with `secrets_encryption_key`.",
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      "omit",
+				ValidateFunc: validation.StringInSlice([]string{"omit", "encrypt"}, false),
+			},
+			"secrets_encryption_key": {
+				Description:  "Base64 encoded 256 bit AES key secrets are encrypted with when `secrets` is `encrypt`.",
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      "",
+				Sensitive:    true,
+				ValidateFunc: validation.StringIsBase64,
+			},
+			"version": {
+				Description: "Version of the export document format.",
+				Type:        schema.TypeInt,
+				Computed:    true,
+			},
+			"document": {
+				Description: "The realm's configuration as a JSON document.",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+			"document_sha256": {
+				Description: "Hex encoded SHA-256 digest of `document`.",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+		},
+	}
+}
+
+func dataSourceRealmExportRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	var encryptionKey []byte
+	if d.Get("secrets").(string) == "encrypt" {
+		var err error
+		encryptionKey, err = base64.StdEncoding.DecodeString(d.Get("secrets_encryption_key").(string))
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		if len(encryptionKey) != 32 {
+			return diag.Errorf("secrets_encryption_key must be a base64 encoded 256 bit key when secrets is encrypt, got %d bits", len(encryptionKey)*8)
+		}
+	}
+
+	toznySDK, err := MakeToznySDK(d, m)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	realmName := d.Get("realm_name").(string)
+
+	export, err := exportRealm(ctx, toznySDK, realmName)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	var exportDocument interface{}
+	if err = toRealmExportValue(export, &exportDocument); err != nil {
+		return diag.FromErr(err)
+	}
+
+	exportDocument, err = protectRealmExportSecrets(exportDocument, encryptionKey)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	document, err := json.MarshalIndent(exportDocument, "", "  ")
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.Set("version", export.Version)
+	d.Set("document", string(document))
+	d.Set("document_sha256", fmt.Sprintf("%x", sha256.Sum256(document)))
+
+	d.SetId(realmName)
+
+	return diags
+}
@@ -0,0 +1,255 @@
+package tozny
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// realmJSONWebKey wraps a public key published in a realm's JSON Web Key Set.
+type realmJSONWebKey struct {
+	KeyID     string   `json:"kid"`
+	KeyType   string   `json:"kty"`
+	Algorithm string   `json:"alg"`
+	Use       string   `json:"use"`
+	Modulus   string   `json:"n"`
+	Exponent  string   `json:"e"`
+	Curve     string   `json:"crv"`
+	X         string   `json:"x"`
+	Y         string   `json:"y"`
+	X5C       []string `json:"x5c"`
+}
+
+// dataSourceRealmJWKS returns the schema and methods for reading the public token verification keys of a Tozny Realm
+func dataSourceRealmJWKS() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceRealmJWKSRead,
+		Schema: map[string]*schema.Schema{
+			"client_credentials_filepath": {
+				Description:   "The filepath to Tozny client credentials for the provider to use when reading the realm's keys.",
+				Type:          schema.TypeString,
+				Optional:      true,
+				Default:       "",
+				ForceNew:      true,
+				ConflictsWith: []string{"client_credentials_config"},
+			},
+			"client_credentials_config": {
+				Description:   "The Tozny account client configuration as a JSON string",
+				Type:          schema.TypeString,
+				Optional:      true,
+				Default:       "",
+				ForceNew:      true,
+				Sensitive:     true,
+				ConflictsWith: []string{"client_credentials_filepath"},
+			},
+			"realm_name": {
+				Description: "Name of the realm to read the keys of.",
+				Type:        schema.TypeString,
+				Required:    true,
+			},
+			"issuer": {
+				Description: "OpenID Connect issuer URL of the realm, whose discovery document names the realm's JWKS URI. Defaults to the issuer served alongside the realm's admin console.",
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+			},
+			"jwks_uri": {
+				Description: "URL the realm publishes its JSON Web Key Set at.",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+			"jwks": {
+				Description: "The realm's JSON Web Key Set as a JSON string.",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+			"keys": {
+				Description: "The keys in the realm's JSON Web Key Set.",
+				Type:        schema.TypeList,
+				Computed:    true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"key_id": {
+							Description: "Identifier (`kid`) of the key.",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+						"key_type": {
+							Description: "Type (`kty`) of the key, e.g. `RSA` or `EC`.",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+						"algorithm": {
+							Description: "Algorithm (`alg`) of the key, e.g. `RS256`.",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+						"use": {
+							Description: "Use (`use`) of the key, e.g. `sig`.",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+						"modulus": {
+							Description: "Base64url encoded modulus (`n`) of an RSA key.",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+						"exponent": {
+							Description: "Base64url encoded exponent (`e`) of an RSA key.",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+						"curve": {
+							Description: "Curve (`crv`) of an EC key.",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+						"x": {
+							Description: "Base64url encoded x coordinate of an EC key.",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+						"y": {
+							Description: "Base64url encoded y coordinate of an EC key.",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+						"certificate": {
+							Description: "PEM encoded certificate (`x5c`) of the key.",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceRealmJWKSRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	toznySDK, err := MakeToznySDK(d, m)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	realm, err := toznySDK.DescribeRealm(ctx, d.Get("realm_name").(string))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	issuer := strings.TrimSuffix(d.Get("issuer").(string), "/")
+	if issuer == "" {
+		// The realm's OpenID Connect issuer is served alongside its admin console
+		adminPathIndex := strings.Index(realm.AdminURL, "/admin/")
+		if adminPathIndex < 0 {
+			return diag.Errorf("unable to determine the issuer of realm %q from admin URL %q, set issuer instead", realm.Domain, realm.AdminURL)
+		}
+		issuer = fmt.Sprintf("%s/realms/%s", realm.AdminURL[:adminPathIndex], realm.Domain)
+	}
+
+	// Take the JWKS URI from the issuer's discovery document rather than assuming where it is served
+	var discovery struct {
+		Issuer  string `json:"issuer"`
+		JWKSURI string `json:"jwks_uri"`
+	}
+	discoveryDocument, err := fetchRealmJWKSDocument(ctx, issuer+"/.well-known/openid-configuration")
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	err = json.Unmarshal(discoveryDocument, &discovery)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	if discovery.Issuer != issuer || discovery.JWKSURI == "" {
+		return diag.Errorf("discovery document of issuer %q is for issuer %q with JWKS URI %q", issuer, discovery.Issuer, discovery.JWKSURI)
+	}
+	jwksURI := discovery.JWKSURI
+
+	jwks, err := fetchRealmJWKSDocument(ctx, jwksURI)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	var keySet struct {
+		Keys []realmJSONWebKey `json:"keys"`
+	}
+	err = json.Unmarshal(jwks, &keySet)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	keys := []interface{}{}
+	for _, key := range keySet.Keys {
+		var certificate string
+		if len(key.X5C) > 0 {
+			certificateDER, err := base64.StdEncoding.DecodeString(key.X5C[0])
+			if err != nil {
+				return diag.Errorf("invalid certificate for key %q: %s", key.KeyID, err)
+			}
+			certificate = string(pem.EncodeToMemory(&pem.Block{
+				Type:  "CERTIFICATE",
+				Bytes: certificateDER,
+			}))
+		}
+		keys = append(keys, map[string]interface{}{
+			"key_id":      key.KeyID,
+			"key_type":    key.KeyType,
+			"algorithm":   key.Algorithm,
+			"use":         key.Use,
+			"modulus":     key.Modulus,
+			"exponent":    key.Exponent,
+			"curve":       key.Curve,
+			"x":           key.X,
+			"y":           key.Y,
+			"certificate": certificate,
+		})
+	}
+
+	d.Set("issuer", issuer)
+	d.Set("jwks_uri", jwksURI)
+	d.Set("jwks", string(jwks))
+
+	if err := d.Set("keys", keys); err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(jwksURI)
+
+	return diags
+}
+
+// fetchRealmJWKSDocument fetches a public OpenID Connect document of a realm, returning the document and error (if any).
+func fetchRealmJWKSDocument(ctx context.Context, documentURL string) ([]byte, error) {
+	request, err := http.NewRequestWithContext(ctx, http.MethodGet, documentURL, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	response, err := http.DefaultClient.Do(request)
+	if err != nil {
+		return nil, err
+	}
+
+	defer response.Body.Close()
+
+	document, err := ioutil.ReadAll(response.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	if response.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("GET %s returned %d: %s", documentURL, response.StatusCode, string(document))
+	}
+
+	return document, nil
+}
@@ -0,0 +1,164 @@
+package tozny
+
+import (
+	"context"
+	"regexp"
+
+	"github.com/google/uuid"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+// dataSourceRealms returns the schema and methods for listing the Tozny Realms visible to a Tozny account
+func dataSourceRealms() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceRealmsRead,
+		Schema: map[string]*schema.Schema{
+			"client_credentials_filepath": {
+				Description:   "The filepath to Tozny client credentials for the provider to use when listing realms.",
+				Type:          schema.TypeString,
+				Optional:      true,
+				Default:       "",
+				ForceNew:      true,
+				ConflictsWith: []string{"client_credentials_config"},
+			},
+			"client_credentials_config": {
+				Description:   "The Tozny account client configuration as a JSON string",
+				Type:          schema.TypeString,
+				Optional:      true,
+				Default:       "",
+				ForceNew:      true,
+				Sensitive:     true,
+				ConflictsWith: []string{"client_credentials_filepath"},
+			},
+			"name_regex": {
+				Description:  "Only list realms whose name matches this regular expression.",
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      "",
+				ValidateFunc: validation.StringIsValidRegExp,
+			},
+			"realm_names": {
+				Description: "The names of the realms matching the specified filters.",
+				Type:        schema.TypeList,
+				Computed:    true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"realms": {
+				Description: "The realms matching the specified filters.",
+				Type:        schema.TypeList,
+				Computed:    true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"realm_name": {
+							Description: "User defined identifier for the realm.",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+						"realm_id": {
+							Description: "Service defined unique identifier for the realm.",
+							Type:        schema.TypeInt,
+							Computed:    true,
+						},
+						"domain": {
+							Description: "Service defined & externally unique reference for the realm.",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+						"admin_url": {
+							Description: "URL for realm administration console.",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+						"active": {
+							Description: "Whether the realm is active for applications and identities to consume.",
+							Type:        schema.TypeBool,
+							Computed:    true,
+						},
+						"broker_identity_tozny_id": {
+							Description: "The Tozny Client ID associated with the Identity used to broker interactions between the realm and it's Identities.",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+						"mpc_enabled": {
+							Description: "Whether MPC is enabled for the Realm.",
+							Type:        schema.TypeBool,
+							Computed:    true,
+						},
+						"secrets_enabled": {
+							Description: "Whether TozSecrets is enabled for the Realm.",
+							Type:        schema.TypeBool,
+							Computed:    true,
+						},
+						"tozid_federation_enabled": {
+							Description: "Whether TozID Federation is enabled for the Realm.",
+							Type:        schema.TypeBool,
+							Computed:    true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceRealmsRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	toznySDK, err := MakeToznySDK(d, m)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	nameRegex, err := regexp.Compile(d.Get("name_regex").(string))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	listedRealms, err := toznySDK.ListRealms(ctx)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	realmNames := []interface{}{}
+	realms := []interface{}{}
+
+	for _, realm := range listedRealms.Realms {
+		if !nameRegex.MatchString(realm.Name) {
+			continue
+		}
+
+		privateRealmInfo, err := toznySDK.PrivateRealmInfo(ctx, realm.Name)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+
+		realmNames = append(realmNames, realm.Name)
+		realms = append(realms, map[string]interface{}{
+			"realm_name":               realm.Name,
+			"realm_id":                 realm.ID,
+			"domain":                   realm.Domain,
+			"admin_url":                realm.AdminURL,
+			"active":                   realm.Active,
+			"broker_identity_tozny_id": realm.BrokerIdentityToznyID,
+			"mpc_enabled":              privateRealmInfo.MPCEnabled,
+			"secrets_enabled":          privateRealmInfo.SecretsEnabled,
+			"tozid_federation_enabled": privateRealmInfo.TozIDFederationEnabled,
+		})
+	}
+
+	if err := d.Set("realm_names", realmNames); err != nil {
+		return diag.FromErr(err)
+	}
+
+	if err := d.Set("realms", realms); err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(uuid.New().String())
+
+	return diags
+}
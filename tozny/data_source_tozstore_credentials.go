@@ -0,0 +1,73 @@
+package tozny
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// dataSourceTozStoreCredentials returns the schema and methods for reading back credentials persisted to a TozStore record
+func dataSourceTozStoreCredentials() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceTozStoreCredentialsRead,
+		Schema: map[string]*schema.Schema{
+			"client_credentials_filepath": {
+				Description:   "The filepath to Tozny client credentials for the client that owns the credentials record.",
+				Type:          schema.TypeString,
+				Optional:      true,
+				Default:       "",
+				ForceNew:      true,
+				ConflictsWith: []string{"client_credentials_config"},
+			},
+			"client_credentials_config": {
+				Description:   "The Tozny client configuration as a JSON string for the client that owns the credentials record.",
+				Type:          schema.TypeString,
+				Optional:      true,
+				Default:       "",
+				ForceNew:      true,
+				Sensitive:     true,
+				ConflictsWith: []string{"client_credentials_filepath"},
+			},
+			"record_id": {
+				Description: "ID of the TozStore record containing the credentials.",
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+			},
+			"record_type": {
+				Description: "The type of the TozStore record containing the credentials.",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+			"credentials": {
+				Description: "The decrypted credentials as a JSON string.",
+				Type:        schema.TypeString,
+				Computed:    true,
+				Sensitive:   true,
+			},
+		},
+	}
+}
+
+func dataSourceTozStoreCredentialsRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	toznySDK, err := MakeToznySDK(d, m)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	recordID := d.Get("record_id").(string)
+
+	credentials, recordType, err := ReadToznyCredentialsRecord(ctx, toznySDK, recordID)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.Set("credentials", credentials)
+	d.Set("record_type", recordType)
+	d.SetId(recordID)
+
+	return diags
+}
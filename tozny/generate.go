@@ -0,0 +1,805 @@
+package tozny
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/tozny/e3db-clients-go/identityClient"
+	"github.com/tozny/e3db-go/v2"
+)
+
+// generatedRealmConfigurationFiles are the files configuration for a realm is generated into, in the order they are written.
+var generatedRealmConfigurationFiles = []string{"realm.tf", "roles.tf", "groups.tf", "applications.tf", "imports.tf"}
+
+// builtInRealmApplications are the client IDs of the applications every realm is created with,
+// which are left out of generated configuration.
+var builtInRealmApplications = map[string]bool{
+	"account":                true,
+	"account-console":        true,
+	"admin-cli":              true,
+	"broker":                 true,
+	"realm-management":       true,
+	"security-admin-console": true,
+}
+
+// builtInRealmRoles are the names of the roles every realm is created with, which are left out of generated configuration.
+var builtInRealmRoles = map[string]bool{
+	"offline_access":    true,
+	"uma_authorization": true,
+}
+
+// GenerateRealmConfiguration enumerates the objects of the named realm and writes Terraform configuration
+// declaring them to the output directory, along with import blocks that bring the existing objects under
+// management so the configuration plans cleanly, returning the paths of the written files, descriptions of
+// the objects that were skipped and must be declared by hand, and error (if any).
+// Existing files are never overwritten.
+func GenerateRealmConfiguration(ctx context.Context, toznySDK *e3db.ToznySDKV3, realmName string, outputDir string) ([]string, []string, error) {
+	generator := realmConfigurationGenerator{
+		toznySDK:        toznySDK,
+		files:           map[string]*hclBody{},
+		comments:        map[string][]string{},
+		labels:          map[string]bool{},
+		roleReferences:  map[string]hclReference{},
+		groupReferences: map[string]hclReference{},
+	}
+
+	steps := []func(context.Context) error{
+		func(ctx context.Context) error { return generator.generateRealm(ctx, realmName) },
+		generator.generateRealmRoles,
+		generator.generateGroups,
+		generator.generateDefaultGroups,
+		generator.generateApplications,
+		generator.generateSkipped,
+	}
+	for _, step := range steps {
+		if err := step(ctx); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	if err := os.MkdirAll(outputDir, 0755); err != nil {
+		return nil, generator.skipped, err
+	}
+
+	var writtenFiles []string
+	for _, fileName := range generatedRealmConfigurationFiles {
+		body, ok := generator.files[fileName]
+		if !ok {
+			continue
+		}
+
+		var content strings.Builder
+		fmt.Fprintf(&content, "# Generated by `terraform-provider-tozny generate` from realm %q.\n", generator.realmName)
+		for _, comment := range generator.comments[fileName] {
+			fmt.Fprintf(&content, "# %s\n", comment)
+		}
+		content.WriteString("\n")
+		body.render(&content, "")
+
+		path := filepath.Join(outputDir, fileName)
+		if err := writeGeneratedFile(path, content.String()); err != nil {
+			return writtenFiles, generator.skipped, err
+		}
+		writtenFiles = append(writtenFiles, path)
+	}
+
+	return writtenFiles, generator.skipped, nil
+}
+
+// writeGeneratedFile writes generated configuration to a file that must not already exist, returning error (if any).
+func writeGeneratedFile(path string, content string) error {
+	file, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0644)
+
+	if err != nil {
+		return err
+	}
+
+	defer file.Close()
+
+	_, err = file.WriteString(content)
+
+	return err
+}
+
+// realmConfigurationGenerator accumulates the generated configuration for a realm.
+type realmConfigurationGenerator struct {
+	toznySDK        *e3db.ToznySDKV3
+	realmName       string
+	realmReference  hclReference
+	files           map[string]*hclBody
+	comments        map[string][]string
+	labels          map[string]bool
+	roleReferences  map[string]hclReference
+	groupReferences map[string]hclReference
+	skipped         []string
+}
+
+// file returns the body of the named generated file.
+func (g *realmConfigurationGenerator) file(fileName string) *hclBody {
+	if _, ok := g.files[fileName]; !ok {
+		g.files[fileName] = &hclBody{}
+	}
+	return g.files[fileName]
+}
+
+// resource adds a resource block to the named file along with an import block for the existing
+// object with the given import ID, returning the body of the resource and its address.
+func (g *realmConfigurationGenerator) resource(fileName string, resourceType string, name string, importID string) (*hclBody, string) {
+	body, address := g.declare(fileName, resourceType, name)
+
+	imported := g.file("imports.tf").block("import")
+	imported.attribute("to", hclReference(address))
+	imported.attribute("id", importID)
+
+	return body, address
+}
+
+// declare adds a resource block without an import block to the named file, for resources that
+// can not be imported, returning the body of the resource and its address.
+func (g *realmConfigurationGenerator) declare(fileName string, resourceType string, name string) (*hclBody, string) {
+	label := g.label(resourceType, name)
+	address := resourceType + "." + label
+
+	return g.file(fileName).block("resource", resourceType, label), address
+}
+
+// note adds a comment line to the top of the named file, once.
+func (g *realmConfigurationGenerator) note(fileName string, comment string) {
+	for _, existing := range g.comments[fileName] {
+		if existing == comment {
+			return
+		}
+	}
+	g.comments[fileName] = append(g.comments[fileName], comment)
+}
+
+// label derives a resource name that is unique for the resource type from the name of an object.
+func (g *realmConfigurationGenerator) label(resourceType string, name string) string {
+	var label strings.Builder
+	for _, r := range strings.ToLower(name) {
+		if (r >= 'a' && r <= 'z') || (r >= '0' && r <= '9') || r == '_' || r == '-' {
+			label.WriteRune(r)
+		} else {
+			label.WriteRune('_')
+		}
+	}
+	base := label.String()
+	if base == "" || !(base[0] >= 'a' && base[0] <= 'z' || base[0] == '_') {
+		base = "_" + base
+	}
+
+	unique := base
+	for suffix := 2; g.labels[resourceType+"."+unique]; suffix++ {
+		unique = fmt.Sprintf("%s_%d", base, suffix)
+	}
+	g.labels[resourceType+"."+unique] = true
+
+	return unique
+}
+
+// generateRealm generates the configuration of the realm itself.
+func (g *realmConfigurationGenerator) generateRealm(ctx context.Context, realmName string) error {
+	listedRealms, err := g.toznySDK.ListRealms(ctx)
+	if err != nil {
+		return err
+	}
+
+	found := false
+	for _, realm := range listedRealms.Realms {
+		if !strings.EqualFold(realm.Name, realmName) {
+			continue
+		}
+		found = true
+		g.realmName = realm.Name
+
+		privateRealmInfo, err := g.toznySDK.PrivateRealmInfo(ctx, realm.Name)
+		if err != nil {
+			return err
+		}
+
+		realmInfo, err := g.toznySDK.RealmInfo(ctx, realm.Name)
+		if err != nil {
+			return err
+		}
+
+		settings, err := describeRealmSettings(ctx, g.toznySDK, realm.Name)
+		if err != nil {
+			return err
+		}
+
+		body, address := g.resource("realm.tf", "tozny_realm", realm.Name, realm.Name)
+		g.realmReference = hclReference(address + ".realm_name")
+
+		body.attribute("realm_name", realm.Name)
+		body.attribute("active", realm.Active)
+		body.attribute("mpc_enabled", privateRealmInfo.MPCEnabled)
+		body.attribute("secrets_enabled", privateRealmInfo.SecretsEnabled)
+		body.attribute("tozid_federation_enabled", privateRealmInfo.TozIDFederationEnabled)
+		if realmInfo.ForgotPasswordCustomLink != "" {
+			body.attribute("forgot_password_custom_link", realmInfo.ForgotPasswordCustomLink)
+		}
+		if realmInfo.ForgotPasswordCustomText != "" {
+			body.attribute("forgot_password_custom_text", realmInfo.ForgotPasswordCustomText)
+		}
+		body.attribute("sso_session_idle_timeout", intSetting(settings.SSOSessionIdleTimeout))
+		body.attribute("sso_session_max_lifespan", intSetting(settings.SSOSessionMaxLifespan))
+		body.attribute("access_token_lifespan", intSetting(settings.AccessTokenLifespan))
+		body.attribute("revoke_refresh_token", boolSetting(settings.RevokeRefreshToken))
+		body.attribute("refresh_token_max_reuse", intSetting(settings.RefreshTokenMaxReuse))
+		body.attribute("offline_session_idle_timeout", intSetting(settings.OfflineSessionIdleTimeout))
+		body.attribute("offline_session_max_lifespan_enabled", boolSetting(settings.OfflineSessionMaxLifespanEnabled))
+		body.attribute("offline_session_max_lifespan", intSetting(settings.OfflineSessionMaxLifespan))
+		body.attribute("login_timeout", intSetting(settings.LoginTimeout))
+		body.attribute("login_action_timeout", intSetting(settings.LoginActionTimeout))
+		body.attribute("registration_allowed", boolSetting(settings.RegistrationAllowed))
+		body.attribute("registration_email_as_username", boolSetting(settings.RegistrationEmailAsUsername))
+		body.attribute("verify_email", boolSetting(settings.VerifyEmail))
+		body.attribute("login_with_email_allowed", boolSetting(settings.LoginWithEmailAllowed))
+		body.attribute("duplicate_emails_allowed", boolSetting(settings.DuplicateEmailsAllowed))
+		body.attribute("remember_me", boolSetting(settings.RememberMe))
+		body.attribute("reset_password_allowed", boolSetting(settings.ResetPasswordAllowed))
+		break
+	}
+
+	if !found {
+		return fmt.Errorf("unable to find realm %q", realmName)
+	}
+
+	return nil
+}
+
+// generateRealmRoles generates the configuration of the realm's roles, other than those every realm is created with.
+func (g *realmConfigurationGenerator) generateRealmRoles(ctx context.Context) error {
+	listedRoles, err := g.toznySDK.ListRealmRoles(ctx, g.realmName)
+	if err != nil {
+		return err
+	}
+
+	sort.SliceStable(listedRoles.Roles, func(i, j int) bool {
+		return listedRoles.Roles[i].Name < listedRoles.Roles[j].Name
+	})
+
+	for _, listedRole := range listedRoles.Roles {
+		if builtInRealmRoles[listedRole.Name] || strings.EqualFold(listedRole.Name, "default-roles-"+g.realmName) {
+			continue
+		}
+
+		role, err := g.toznySDK.DescribeRealmRole(ctx, identityClient.DescribeRealmRoleRequest{
+			RealmName: strings.ToLower(g.realmName),
+			RoleID:    listedRole.ID,
+		})
+		if err != nil {
+			return err
+		}
+
+		body, address := g.resource("roles.tf", "tozny_realm_role", role.Name, g.realmName+"/"+role.ID)
+		g.roleReferences[role.ID] = hclReference(address + ".realm_role_id")
+
+		body.attribute("realm_name", g.realmReference)
+		body.attribute("name", role.Name)
+		body.attribute("description", role.Description)
+		generateAttributes(body, role.Attributes)
+	}
+
+	return nil
+}
+
+// generateGroups generates the configuration of the realm's groups along with their access policies.
+func (g *realmConfigurationGenerator) generateGroups(ctx context.Context) error {
+	listedGroups, err := g.toznySDK.ListRealmGroups(ctx, identityClient.ListRealmGroupsRequest{
+		RealmName: g.realmName,
+	})
+	if err != nil {
+		return err
+	}
+
+	sort.SliceStable(listedGroups.Groups, func(i, j int) bool {
+		return listedGroups.Groups[i].Name < listedGroups.Groups[j].Name
+	})
+
+	for _, listedGroup := range listedGroups.Groups {
+		group, err := g.toznySDK.DescribeRealmGroup(ctx, identityClient.DescribeRealmGroupRequest{
+			RealmName: strings.ToLower(g.realmName),
+			GroupID:   listedGroup.ID,
+		})
+		if err != nil {
+			return err
+		}
+
+		accessPolicies, err := g.toznySDK.ListAccessPolicies(ctx, identityClient.ListAccessPoliciesRequest{
+			RealmName: g.realmName,
+			GroupIDs:  []string{group.ID},
+		})
+		if err != nil {
+			return err
+		}
+
+		body, address := g.resource("groups.tf", "tozny_realm_group", group.Name, g.realmName+"/"+group.ID)
+		g.groupReferences[group.ID] = hclReference(address + ".group_id")
+		body.attribute("realm_name", g.realmReference)
+		body.attribute("name", group.Name)
+		generateAttributes(body, group.Attributes)
+
+		for _, groupAccessPolicies := range accessPolicies.GroupAccessPolicies {
+			for _, policy := range groupAccessPolicies.AccessPolicies {
+				// Reference approval roles declared in the generated configuration where possible
+				approvalRoleIDs := []interface{}{}
+				for _, role := range policy.ApprovalRoles {
+					if reference, ok := g.roleReferences[role.ID]; ok {
+						approvalRoleIDs = append(approvalRoleIDs, reference)
+					} else {
+						approvalRoleIDs = append(approvalRoleIDs, role.ID)
+					}
+				}
+
+				policyBody := body.block("access_policy")
+				policyBody.attribute("approval_role_ids", approvalRoleIDs)
+				policyBody.attribute("required_approvals", policy.RequiredApprovals)
+				if policy.MaximumAccessDurationSeconds != 0 {
+					policyBody.attribute("maximum_access_duration_seconds", policy.MaximumAccessDurationSeconds)
+				}
+				if policy.PluginType != "" {
+					policyBody.attribute("plugin_type", policy.PluginType)
+				}
+				if policy.PluginID != "" {
+					policyBody.attribute("plugin_id", policy.PluginID)
+				}
+				if policy.PluginMPCFlowSource != "" {
+					policyBody.attribute("plugin_mpc_flow_source", policy.PluginMPCFlowSource)
+				}
+			}
+		}
+
+		err = g.generateGroupRoleMappings(ctx, group.Name, group.ID)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// generateGroupRoleMappings generates the configuration of the roles mapped to a group, if any.
+func (g *realmConfigurationGenerator) generateGroupRoleMappings(ctx context.Context, groupName string, groupID string) error {
+	roleMappings, err := g.toznySDK.ListGroupRoleMappings(ctx, identityClient.ListGroupRoleMappingsRequest{
+		RealmName: strings.ToLower(g.realmName),
+		GroupID:   groupID,
+	})
+	if err != nil {
+		return err
+	}
+
+	applicationIDs := make([]string, 0, len(roleMappings.ClientRoles))
+	for applicationID, roles := range roleMappings.ClientRoles {
+		if len(roles) > 0 {
+			applicationIDs = append(applicationIDs, applicationID)
+		}
+	}
+	if len(applicationIDs) == 0 && len(roleMappings.RealmRoles) == 0 {
+		return nil
+	}
+	sort.Strings(applicationIDs)
+
+	g.note("groups.tf", "tozny_realm_group_role_mappings can not be imported, applying it maps the roles the group already has.")
+	body, _ := g.declare("groups.tf", "tozny_realm_group_role_mappings", groupName)
+	body.attribute("realm_name", g.realmReference)
+	body.attribute("group_id", g.groupReferences[groupID])
+
+	for _, applicationID := range applicationIDs {
+		roles := roleMappings.ClientRoles[applicationID]
+		sort.SliceStable(roles, func(i, j int) bool {
+			return roles[i].Name < roles[j].Name
+		})
+		for _, role := range roles {
+			roleBody := body.block("application_role")
+			roleBody.attribute("application_id", applicationID)
+			roleBody.attribute("role_id", role.ID)
+			roleBody.attribute("role_name", role.Name)
+		}
+	}
+
+	realmRoles := roleMappings.RealmRoles
+	sort.SliceStable(realmRoles, func(i, j int) bool {
+		return realmRoles[i].Name < realmRoles[j].Name
+	})
+	for _, role := range realmRoles {
+		roleBody := body.block("realm_role")
+		roleBody.attribute("realm_id", role.ContainerID)
+		if reference, ok := g.roleReferences[role.ID]; ok {
+			roleBody.attribute("role_id", reference)
+		} else {
+			roleBody.attribute("role_id", role.ID)
+		}
+		roleBody.attribute("role_name", role.Name)
+	}
+
+	return nil
+}
+
+// generateDefaultGroups generates the configuration of the groups identities join when they are created, if any.
+func (g *realmConfigurationGenerator) generateDefaultGroups(ctx context.Context) error {
+	defaultGroups, err := g.toznySDK.ListRealmDefaultGroups(ctx, identityClient.ListRealmGroupsRequest{
+		RealmName: g.realmName,
+	})
+	if err != nil {
+		return err
+	}
+	if len(defaultGroups.Groups) == 0 {
+		return nil
+	}
+
+	groups := defaultGroups.Groups
+	sort.SliceStable(groups, func(i, j int) bool {
+		return groups[i].Name < groups[j].Name
+	})
+
+	groupIDs := []interface{}{}
+	for _, group := range groups {
+		if reference, ok := g.groupReferences[group.ID]; ok {
+			groupIDs = append(groupIDs, reference)
+		} else {
+			groupIDs = append(groupIDs, group.ID)
+		}
+	}
+
+	g.note("groups.tf", "tozny_realm_default_groups can not be imported, applying it sets the default groups the realm already has.")
+	body, _ := g.declare("groups.tf", "tozny_realm_default_groups", g.realmName)
+	body.attribute("realm_name", g.realmReference)
+	body.attribute("group_ids", groupIDs)
+
+	return nil
+}
+
+// generateApplications generates the configuration of the realm's applications, other than those every
+// realm is created with, along with the roles of each application.
+func (g *realmConfigurationGenerator) generateApplications(ctx context.Context) error {
+	listedApplications, err := g.toznySDK.ListRealmApplications(ctx, g.realmName)
+	if err != nil {
+		return err
+	}
+
+	sort.SliceStable(listedApplications.Applications, func(i, j int) bool {
+		return listedApplications.Applications[i].ClientID < listedApplications.Applications[j].ClientID
+	})
+
+	for _, listedApplication := range listedApplications.Applications {
+		if builtInRealmApplications[listedApplication.ClientID] {
+			continue
+		}
+
+		application, err := g.toznySDK.DescribeRealmApplication(ctx, identityClient.DeleteRealmApplicationRequest{
+			RealmName:     g.realmName,
+			ApplicationID: listedApplication.ID,
+		})
+		if err != nil {
+			return err
+		}
+
+		body, address := g.resource("applications.tf", "tozny_realm_application", application.ClientID, g.realmName+"/"+application.ID)
+		body.attribute("realm_name", g.realmReference)
+		body.attribute("client_id", application.ClientID)
+		body.attribute("name", application.Name)
+		body.attribute("protocol", application.Protocol)
+		body.attribute("active", application.Active)
+
+		if strings.EqualFold(application.Protocol, "saml") {
+			settings := body.block("saml_settings")
+			if len(application.AllowedOrigins) > 0 {
+				settings.attribute("allowed_origins", application.AllowedOrigins)
+			}
+			if application.SAMLSettings.DefaultEndpoint != "" {
+				settings.attribute("default_endpoint", application.SAMLSettings.DefaultEndpoint)
+			}
+			settings.attribute("include_authn_statement", application.SAMLSettings.IncludeAuthnStatement)
+			settings.attribute("include_one_time_use_condition", application.SAMLSettings.IncludeOneTimeUseCondition)
+			settings.attribute("sign_documents", application.SAMLSettings.SignDocuments)
+			settings.attribute("sign_assertions", application.SAMLSettings.SignAssertions)
+			settings.attribute("client_signature_required", application.SAMLSettings.ClientSignatureRequired)
+			settings.attribute("force_post_binding", application.SAMLSettings.ForcePostBinding)
+			settings.attribute("force_name_id_format", application.SAMLSettings.ForceNameIDFormat)
+			if application.SAMLSettings.NameIDFormat != "" {
+				settings.attribute("name_id_format", application.SAMLSettings.NameIDFormat)
+			}
+			if application.SAMLSettings.IDPInitiatedSSOURLName != "" {
+				settings.attribute("idp_initiated_sso_url_name", application.SAMLSettings.IDPInitiatedSSOURLName)
+			}
+			if application.SAMLSettings.AssertionConsumerServicePOSTBindingURL != "" {
+				settings.attribute("assertion_consumer_service_post_binding_url", application.SAMLSettings.AssertionConsumerServicePOSTBindingURL)
+			}
+		} else {
+			settings := body.block("oidc_settings")
+			if len(application.AllowedOrigins) > 0 {
+				settings.attribute("allowed_origins", application.AllowedOrigins)
+			}
+			settings.attribute("access_type", application.OIDCSettings.AccessType)
+			if application.OIDCSettings.RootURL != "" {
+				settings.attribute("root_url", application.OIDCSettings.RootURL)
+			}
+			if application.OIDCSettings.BaseURL != "" {
+				settings.attribute("base_url", application.OIDCSettings.BaseURL)
+			}
+			settings.attribute("standard_flow_enabled", application.OIDCSettings.StandardFlowEnabled)
+			settings.attribute("implicit_flow_enabled", application.OIDCSettings.ImplicitFlowEnabled)
+			settings.attribute("direct_access_grants_enabled", application.OIDCSettings.DirectAccessGrantsEnabled)
+		}
+
+		listedRoles, err := listRealmExportItems(ctx, g.toznySDK, fmt.Sprintf("%s/application/%s/role", realmPath(g.realmName), url.PathEscape(application.ID)), "application_roles")
+		if err != nil {
+			return err
+		}
+		sortRealmExportItems(listedRoles)
+
+		for _, listedRole := range listedRoles {
+			roleName, ok := listedRole.(map[string]interface{})["name"].(string)
+			if !ok {
+				return fmt.Errorf("unable to generate role %v of application %q without a name", listedRole, application.ClientID)
+			}
+
+			role, err := g.toznySDK.DescribeRealmApplicationRole(ctx, identityClient.DescribeRealmApplicationRoleRequest{
+				RealmName:           strings.ToLower(g.realmName),
+				ApplicationID:       application.ID,
+				ApplicationRoleName: roleName,
+			})
+			if err != nil {
+				return err
+			}
+
+			roleBody, _ := g.resource("applications.tf", "tozny_realm_application_role", application.ClientID+"_"+role.Name, g.realmName+"/"+application.ID+"/"+role.Name)
+			roleBody.attribute("realm_name", g.realmReference)
+			roleBody.attribute("application_id", hclReference(address+".application_id"))
+			roleBody.attribute("name", role.Name)
+			roleBody.attribute("description", role.Description)
+		}
+
+		err = g.generateApplicationMappers(ctx, application.ID, application.ClientID, hclReference(address+".application_id"))
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// generateApplicationMappers generates the configuration of the mappers of an application.
+func (g *realmConfigurationGenerator) generateApplicationMappers(ctx context.Context, applicationID string, clientID string, applicationReference hclReference) error {
+	listedMappers, err := listRealmExportItems(ctx, g.toznySDK, fmt.Sprintf("%s/application/%s/mapper", realmPath(g.realmName), url.PathEscape(applicationID)), "application_mappers")
+	if err != nil {
+		return err
+	}
+	sortRealmExportItems(listedMappers)
+
+	for _, listedMapper := range listedMappers {
+		mapperID, ok := listedMapper.(map[string]interface{})["id"].(string)
+		if !ok {
+			return fmt.Errorf("unable to generate mapper %v of application %q without an ID", listedMapper, clientID)
+		}
+
+		mapper, err := g.toznySDK.DescribeRealmApplicationMapper(ctx, identityClient.DescribeRealmApplicationMapperRequest{
+			RealmName:           g.realmName,
+			ApplicationID:       applicationID,
+			ApplicationMapperID: mapperID,
+		})
+		if err != nil {
+			return err
+		}
+
+		body, _ := g.resource("applications.tf", "tozny_realm_application_mapper", clientID+"_"+mapper.Name, g.realmName+"/"+applicationID+"/"+mapper.ID)
+		body.attribute("realm_name", g.realmReference)
+		body.attribute("application_id", applicationReference)
+		body.attribute("name", mapper.Name)
+		body.attribute("protocol", mapper.Protocol)
+		body.attribute("mapper_type", mapper.MapperType)
+
+		optionalAttributes := []struct {
+			name  string
+			value string
+		}{
+			{"user_session_note", mapper.UserSessionNote},
+			{"user_attribute", mapper.UserAttribute},
+			{"token_claim_name", mapper.TokenClaimName},
+			{"claim_json_type", mapper.ClaimJSONType},
+			{"saml_attribute_name", mapper.SAMLAttributeName},
+			{"saml_attribute_name_format", mapper.SAMLAttributeNameFormat},
+			{"friendly_name", mapper.FriendlyName},
+			{"role_attribute_name", mapper.RoleAttributeName},
+			{"property", mapper.Property},
+		}
+		for _, attribute := range optionalAttributes {
+			if attribute.value != "" {
+				body.attribute(attribute.name, attribute.value)
+			}
+		}
+
+		body.attribute("full_group_path", mapper.FullPath)
+		body.attribute("add_to_id_token", mapper.AddToIDToken)
+		body.attribute("add_to_access_token", mapper.AddToAccessToken)
+		body.attribute("add_to_user_info", mapper.AddToUserInfo)
+		body.attribute("multivalued", mapper.Multivalued)
+		body.attribute("aggregate_attribute_values", mapper.AggregateAttributeValues)
+		body.attribute("single_role_attribute", mapper.SingleRoleAttribute)
+		// Always set as their defaults do not match the empty values read back for mappers without them
+		body.attribute("realm_role_prefix", mapper.RealmRolePrefix)
+		body.attribute("client_id", mapper.ClientRoleClientID)
+		body.attribute("client_role_prefix", mapper.ClientRolePrefix)
+	}
+
+	return nil
+}
+
+// generateSkipped records the objects of the realm that are not generated because their
+// secrets can not be read back from the realm, so they must be declared by hand.
+func (g *realmConfigurationGenerator) generateSkipped(ctx context.Context) error {
+	skippedKinds := []struct {
+		path         string
+		wrapperKey   string
+		description  string
+		resourceType string
+	}{
+		{"/provider", "providers", "LDAP provider", "tozny_realm_provider"},
+		{"/identity-provider", "identity_providers", "identity provider", "tozny_identity_provider"},
+	}
+
+	for _, kind := range skippedKinds {
+		items, err := listRealmExportItems(ctx, g.toznySDK, realmPath(g.realmName)+kind.path, kind.wrapperKey)
+		if err != nil {
+			return err
+		}
+		sortRealmExportItems(items)
+		for _, item := range items {
+			g.skipped = append(g.skipped, fmt.Sprintf("%s %q (%s)", kind.description, realmExportItemName(item), kind.resourceType))
+		}
+	}
+
+	if len(g.skipped) > 0 {
+		g.file("imports.tf")
+		g.comments["imports.tf"] = append(g.comments["imports.tf"],
+			"",
+			"The following objects were not generated as their bind credentials and client secrets",
+			"can not be read back from the realm, declare them by hand:",
+		)
+		for _, skipped := range g.skipped {
+			g.comments["imports.tf"] = append(g.comments["imports.tf"], "  "+skipped)
+		}
+	}
+
+	return nil
+}
+
+// generateAttributes adds the attribute blocks of a role or group, ordered by key as they are read back.
+func generateAttributes(body *hclBody, attributes map[string][]string) {
+	keys := make([]string, 0, len(attributes))
+	for key := range attributes {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		attribute := body.block("attribute")
+		attribute.attribute("key", key)
+		attribute.attribute("values", attributes[key])
+	}
+}
+
+// hclReference is a reference to an object in generated configuration, rendered as an unquoted expression.
+type hclReference string
+
+// hclBody is the body of a file or block of generated configuration, rendered in the order items are added.
+type hclBody struct {
+	items []hclItem
+}
+
+// hclItem is either an attribute, with its value rendered as an expression, or a nested block.
+type hclItem struct {
+	name       string
+	expression string
+	labels     []string
+	body       *hclBody
+}
+
+// attribute adds an attribute with the given value to the body.
+func (b *hclBody) attribute(name string, value interface{}) {
+	b.items = append(b.items, hclItem{name: name, expression: hclExpression(value)})
+}
+
+// block adds a nested block with the given labels to the body, returning the body of the block.
+func (b *hclBody) block(name string, labels ...string) *hclBody {
+	body := &hclBody{}
+	b.items = append(b.items, hclItem{name: name, labels: labels, body: body})
+	return body
+}
+
+// render writes the body at the given indentation, aligning consecutive attributes as `terraform fmt` does.
+func (b *hclBody) render(out *strings.Builder, indent string) {
+	for i := 0; i < len(b.items); i++ {
+		if i > 0 {
+			out.WriteString("\n")
+		}
+
+		if item := b.items[i]; item.body != nil {
+			out.WriteString(indent + item.name)
+			for _, label := range item.labels {
+				out.WriteString(" " + hclQuote(label))
+			}
+			out.WriteString(" {\n")
+			item.body.render(out, indent+"  ")
+			out.WriteString(indent + "}\n")
+			continue
+		}
+
+		end := i
+		width := 0
+		for ; end < len(b.items) && b.items[end].body == nil; end++ {
+			if len(b.items[end].name) > width {
+				width = len(b.items[end].name)
+			}
+		}
+		for ; i < end; i++ {
+			fmt.Fprintf(out, "%s%-*s = %s\n", indent, width, b.items[i].name, b.items[i].expression)
+		}
+		i--
+	}
+}
+
+// hclExpression renders a value as an HCL expression.
+func hclExpression(value interface{}) string {
+	switch typedValue := value.(type) {
+	case hclReference:
+		return string(typedValue)
+	case string:
+		return hclQuote(typedValue)
+	case bool:
+		return strconv.FormatBool(typedValue)
+	case int:
+		return strconv.Itoa(typedValue)
+	case int64:
+		return strconv.FormatInt(typedValue, 10)
+	case []string:
+		values := make([]interface{}, len(typedValue))
+		for i, item := range typedValue {
+			values[i] = item
+		}
+		return hclExpression(values)
+	case []interface{}:
+		expressions := make([]string, len(typedValue))
+		for i, item := range typedValue {
+			expressions[i] = hclExpression(item)
+		}
+		return "[" + strings.Join(expressions, ", ") + "]"
+	default:
+		return hclQuote(fmt.Sprint(typedValue))
+	}
+}
+
+// hclQuote renders a string as a quoted HCL string, escaping template sequences so the string is used literally.
+func hclQuote(value string) string {
+	var quoted strings.Builder
+	quoted.WriteByte('"')
+	for i, r := range value {
+		switch {
+		case r == '"' || r == '\\':
+			quoted.WriteRune('\\')
+			quoted.WriteRune(r)
+		case r == '\n':
+			quoted.WriteString(`\n`)
+		case r == '\r':
+			quoted.WriteString(`\r`)
+		case r == '\t':
+			quoted.WriteString(`\t`)
+		case (r == '$' || r == '%') && strings.HasPrefix(value[i+1:], "{"):
+			quoted.WriteRune(r)
+			quoted.WriteRune(r)
+		case r < 0x20 || r == 0x7f:
+			fmt.Fprintf(&quoted, `\u%04x`, r)
+		default:
+			quoted.WriteRune(r)
+		}
+	}
+	quoted.WriteByte('"')
+	return quoted.String()
+}
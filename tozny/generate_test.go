@@ -0,0 +1,126 @@
+package tozny
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestHCLQuote(t *testing.T) {
+	cases := map[string]string{
+		"":                     `""`,
+		"plain":                `"plain"`,
+		`say "hi"`:             `"say \"hi\""`,
+		`C:\path`:              `"C:\\path"`,
+		"line\nbreak\ttab\r":   `"line\nbreak\ttab\r"`,
+		"${var.name}":          `"$${var.name}"`,
+		"%{ if true }":         `"%%{ if true }"`,
+		"$5 and 100%":          `"$5 and 100%"`,
+		"bell\a":               `"bell\u0007"`,
+		"delete\x7f":           `"delete\u007f"`,
+		"unicode ünïcødé ✓":    `"unicode ünïcødé ✓"`,
+		"trailing $ and % {":   `"trailing $ and % {"`,
+		"${nested} ${twice}":   `"$${nested} $${twice}"`,
+		"\"${quoted}\"":        `"\"$${quoted}\""`,
+		"<b>html</b> & more\n": `"<b>html</b> & more\n"`,
+	}
+
+	for value, expected := range cases {
+		if quoted := hclQuote(value); quoted != expected {
+			t.Errorf("hclQuote(%q) = %s, expected %s", value, quoted, expected)
+		}
+	}
+}
+
+func TestHCLExpression(t *testing.T) {
+	cases := []struct {
+		value    interface{}
+		expected string
+	}{
+		{hclReference("tozny_realm.realm.realm_name"), "tozny_realm.realm.realm_name"},
+		{"text", `"text"`},
+		{true, "true"},
+		{42, "42"},
+		{int64(-7), "-7"},
+		{[]string{}, "[]"},
+		{[]string{"a", "b"}, `["a", "b"]`},
+		{[]interface{}{hclReference("tozny_realm_group.admins.group_id"), "x", false}, `[tozny_realm_group.admins.group_id, "x", false]`},
+		{3.5, `"3.5"`},
+	}
+
+	for _, c := range cases {
+		if expression := hclExpression(c.value); expression != c.expected {
+			t.Errorf("hclExpression(%#v) = %s, expected %s", c.value, expression, c.expected)
+		}
+	}
+}
+
+func TestRealmConfigurationGeneratorLabel(t *testing.T) {
+	g := &realmConfigurationGenerator{
+		labels: map[string]bool{},
+	}
+
+	cases := []struct {
+		resourceType string
+		name         string
+		expected     string
+	}{
+		{"tozny_realm_group", "Admins", "admins"},
+		{"tozny_realm_group", "Help Desk/Tier 1", "help_desk_tier_1"},
+		{"tozny_realm_group", "with-dash_and_underscore", "with-dash_and_underscore"},
+		{"tozny_realm_group", "1st", "_1st"},
+		{"tozny_realm_group", "-leading", "_-leading"},
+		{"tozny_realm_group", "", "_"},
+		{"tozny_realm_group", "Ünïcode", "_n_code"},
+		// Names that sanitize to a label in use get a numeric suffix
+		{"tozny_realm_group", "admins", "admins_2"},
+		{"tozny_realm_group", "ADMINS", "admins_3"},
+		// Labels only need to be unique for their resource type
+		{"tozny_realm_role", "admins", "admins"},
+	}
+
+	for _, c := range cases {
+		if label := g.label(c.resourceType, c.name); label != c.expected {
+			t.Errorf("label(%q, %q) = %q, expected %q", c.resourceType, c.name, label, c.expected)
+		}
+	}
+}
+
+func TestHCLBodyRender(t *testing.T) {
+	var file hclBody
+
+	realm := file.block("resource", "tozny_realm", "realm")
+	realm.attribute("realm_name", "example")
+	realm.attribute("sovereign_name", "Administrator")
+	realm.attribute("active", true)
+	sovereign := realm.block("sovereign")
+	sovereign.attribute("name", "admin")
+	realm.attribute("mpc_enabled", false)
+
+	imported := file.block("import")
+	imported.attribute("to", hclReference("tozny_realm.realm"))
+	imported.attribute("id", "example")
+
+	var out strings.Builder
+	file.render(&out, "")
+
+	expected := `resource "tozny_realm" "realm" {
+  realm_name     = "example"
+  sovereign_name = "Administrator"
+  active         = true
+
+  sovereign {
+    name = "admin"
+  }
+
+  mpc_enabled = false
+}
+
+import {
+  to = tozny_realm.realm
+  id = "example"
+}
+`
+	if out.String() != expected {
+		t.Errorf("rendered\n%s\nexpected\n%s", out.String(), expected)
+	}
+}
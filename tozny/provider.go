@@ -49,6 +49,19 @@ func Provider() *schema.Provider {
 			"tozny_realm_broker_delegation":          resourceRealmBrokerDelegation(),
 			"tozny_realm":                            resourceRealm(),
 			"tozny_realm_role":                       resourceRealmRole(),
+			"tozny_realm_brute_force_protection":     resourceRealmBruteForceProtection(),
+			"tozny_realm_otp_policy":                 resourceRealmOTPPolicy(),
+			"tozny_realm_webauthn_policy":            resourceRealmWebAuthnPolicy(),
+			"tozny_realm_events":                     resourceRealmEvents(),
+			"tozny_realm_required_action":            resourceRealmRequiredAction(),
+			"tozny_realm_authentication_flow":        resourceRealmAuthenticationFlow(),
+			"tozny_realm_authentication_execution":   resourceRealmAuthenticationExecution(),
+			"tozny_realm_user_profile":               resourceRealmUserProfile(),
+			"tozny_realm_key":                        resourceRealmKey(),
+			"tozny_realm_localization":               resourceRealmLocalization(),
+			"tozny_realm_theme":                      resourceRealmTheme(),
+			"tozny_realm_smtp":                       resourceRealmSMTP(),
+			"tozny_realm_password_policy":            resourceRealmPasswordPolicy(),
 			"tozny_realm_application":                resourceRealmApplication(),
 			"tozny_realm_application_mapper":         resourceRealmApplicationMapper(),
 			"tozny_realm_application_client_secret":  resourceRealmApplicationClientSecret(),
@@ -68,10 +81,18 @@ func Provider() *schema.Provider {
 			"tozny_identity_provider_mapper":         resourceIdentityProviderMapper(),
 		},
 		DataSourcesMap: map[string]*schema.Resource{
+			"tozny_account":                            dataSourceAccount(),
+			"tozny_client_registration_tokens":         dataSourceClientRegistrationTokens(),
+			"tozny_realms":                             dataSourceRealms(),
+			"tozny_realm":                              dataSourceRealm(),
+			"tozny_realm_events":                       dataSourceRealmEvents(),
+			"tozny_realm_jwks":                         dataSourceRealmJWKS(),
+			"tozny_realm_export":                       dataSourceRealmExport(),
 			"tozny_realm_application":                  dataSourceRealmApplication(),
 			"tozny_realm_application_role":             dataSourceRealmApplicationRole(),
 			"tozny_realm_application_saml_description": dataSourceRealmApplicationSAMLDescription(),
 			"tozny_realm_role":                         dataSourceRealmRole(),
+			"tozny_tozstore_credentials":               dataSourceTozStoreCredentials(),
 		},
 		ConfigureContextFunc: providerConfigure,
 	}
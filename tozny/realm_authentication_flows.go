@@ -0,0 +1,94 @@
+package tozny
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+
+	"github.com/tozny/e3db-go/v2"
+)
+
+// realmAuthenticationFlow wraps a flow of steps identities complete to authenticate with a realm.
+type realmAuthenticationFlow struct {
+	ID          string `json:"id,omitempty"`
+	Alias       string `json:"alias"`
+	Description string `json:"description"`
+	ProviderID  string `json:"provider_id"`
+	TopLevel    bool   `json:"top_level"`
+	BuiltIn     bool   `json:"built_in"`
+}
+
+// realmAuthenticationFlowCopy wraps a request to copy an authentication flow.
+type realmAuthenticationFlowCopy struct {
+	NewName string `json:"new_name"`
+}
+
+// realmAuthenticationExecution wraps a step of an authentication flow, which is either
+// an authenticator or a sub flow of further steps.
+type realmAuthenticationExecution struct {
+	ID            string            `json:"id,omitempty"`
+	Authenticator string            `json:"authenticator,omitempty"`
+	SubFlowAlias  string            `json:"sub_flow_alias,omitempty"`
+	SubFlowType   string            `json:"sub_flow_type,omitempty"`
+	Requirement   string            `json:"requirement"`
+	Priority      int               `json:"priority"`
+	Config        map[string]string `json:"config,omitempty"`
+}
+
+// realmFlowBindings maps the name of each realm authentication flow binding to its
+// setting on the realm and the alias of the service default flow for the binding.
+var realmFlowBindings = map[string]struct {
+	setting      func(settings *realmSettings) **string
+	defaultAlias string
+}{
+	"browser":               {func(settings *realmSettings) **string { return &settings.BrowserFlow }, "browser"},
+	"direct_grant":          {func(settings *realmSettings) **string { return &settings.DirectGrantFlow }, "direct grant"},
+	"registration":          {func(settings *realmSettings) **string { return &settings.RegistrationFlow }, "registration"},
+	"reset_credentials":     {func(settings *realmSettings) **string { return &settings.ResetCredentialsFlow }, "reset credentials"},
+	"client_authentication": {func(settings *realmSettings) **string { return &settings.ClientAuthenticationFlow }, "clients"},
+	"docker":                {func(settings *realmSettings) **string { return &settings.DockerAuthenticationFlow }, "docker auth"},
+}
+
+// realmAuthenticationFlowsPath returns the identity service path for the authentication flows of the named realm.
+func realmAuthenticationFlowsPath(realmName string) string {
+	return fmt.Sprintf("/v1/identity/realm/%s/authentication/flows", url.PathEscape(realmName))
+}
+
+// realmAuthenticationFlowPath returns the identity service path for an authentication flow of the named realm.
+func realmAuthenticationFlowPath(realmName string, flowID string) string {
+	return fmt.Sprintf("%s/%s", realmAuthenticationFlowsPath(realmName), url.PathEscape(flowID))
+}
+
+// realmAuthenticationExecutionsPath returns the identity service path for the executions of the authentication flow with the given alias.
+func realmAuthenticationExecutionsPath(realmName string, flowAlias string) string {
+	return fmt.Sprintf("%s/%s/executions", realmAuthenticationFlowsPath(realmName), url.PathEscape(flowAlias))
+}
+
+// realmAuthenticationExecutionPath returns the identity service path for an execution of the authentication flow with the given alias.
+func realmAuthenticationExecutionPath(realmName string, flowAlias string, executionID string) string {
+	return fmt.Sprintf("%s/%s", realmAuthenticationExecutionsPath(realmName, flowAlias), url.PathEscape(executionID))
+}
+
+// realmApplicationFlowOverridesPath returns the identity service path for the authentication flows an application of the named realm uses instead of the realm's flows.
+func realmApplicationFlowOverridesPath(realmName string, applicationID string) string {
+	return fmt.Sprintf("/v1/identity/realm/%s/application/%s/flow-overrides", url.PathEscape(realmName), url.PathEscape(applicationID))
+}
+
+// listRealmApplicationFlowOverrides fetches the flow overrides of an application of the named realm, keyed by binding, returning error (if any).
+func listRealmApplicationFlowOverrides(ctx context.Context, toznySDK *e3db.ToznySDKV3, realmName string, applicationID string) (map[string]string, error) {
+	overrides := map[string]string{}
+
+	err := makeIdentityServiceCall(ctx, toznySDK, http.MethodGet, realmApplicationFlowOverridesPath(realmName, applicationID), nil, &overrides)
+	if err != nil {
+		return nil, err
+	}
+
+	return overrides, nil
+}
+
+// updateRealmApplicationFlowOverrides applies flow overrides, keyed by binding, to an application of the named realm,
+// with an empty flow ID removing the override for a binding, returning error (if any).
+func updateRealmApplicationFlowOverrides(ctx context.Context, toznySDK *e3db.ToznySDKV3, realmName string, applicationID string, overrides map[string]string) error {
+	return makeIdentityServiceCall(ctx, toznySDK, http.MethodPatch, realmApplicationFlowOverridesPath(realmName, applicationID), overrides, nil)
+}
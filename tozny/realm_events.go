@@ -0,0 +1,199 @@
+package tozny
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/tozny/e3db-go/v2"
+)
+
+// realmEventsConfig wraps how a realm captures and keeps login and admin events.
+type realmEventsConfig struct {
+	EventsEnabled             bool     `json:"events_enabled"`
+	EventsExpiration          int      `json:"events_expiration"`
+	EnabledEventTypes         []string `json:"enabled_event_types"`
+	EventsListeners           []string `json:"events_listeners"`
+	AdminEventsEnabled        bool     `json:"admin_events_enabled"`
+	AdminEventsDetailsEnabled bool     `json:"admin_events_details_enabled"`
+}
+
+// realmEventsConfigPath returns the identity service path for the events config of the named realm.
+func realmEventsConfigPath(realmName string) string {
+	return fmt.Sprintf("/v1/identity/realm/%s/events/config", url.PathEscape(realmName))
+}
+
+// describeRealmEventsConfig fetches the events config of the named realm, returning error (if any).
+func describeRealmEventsConfig(ctx context.Context, toznySDK *e3db.ToznySDKV3, realmName string) (*realmEventsConfig, error) {
+	var config realmEventsConfig
+
+	err := makeIdentityServiceCall(ctx, toznySDK, http.MethodGet, realmEventsConfigPath(realmName), nil, &config)
+	if err != nil {
+		return nil, err
+	}
+
+	return &config, nil
+}
+
+// updateRealmEventsConfig replaces the events config of the named realm, returning error (if any).
+func updateRealmEventsConfig(ctx context.Context, toznySDK *e3db.ToznySDKV3, realmName string, config realmEventsConfig) error {
+	return makeIdentityServiceCall(ctx, toznySDK, http.MethodPut, realmEventsConfigPath(realmName), config, nil)
+}
+
+// realmEvent wraps a login event saved by a realm.
+type realmEvent struct {
+	Time      int64             `json:"time"`
+	Type      string            `json:"type"`
+	UserID    string            `json:"user_id"`
+	ClientID  string            `json:"client_id"`
+	SessionID string            `json:"session_id"`
+	IPAddress string            `json:"ip_address"`
+	Error     string            `json:"error"`
+	Details   map[string]string `json:"details"`
+}
+
+// realmAdminEventAuthDetails wraps who performed the action recorded by an admin event.
+type realmAdminEventAuthDetails struct {
+	UserID    string `json:"user_id"`
+	ClientID  string `json:"client_id"`
+	IPAddress string `json:"ip_address"`
+}
+
+// realmAdminEvent wraps an admin event saved by a realm.
+type realmAdminEvent struct {
+	Time           int64                      `json:"time"`
+	OperationType  string                     `json:"operation_type"`
+	ResourceType   string                     `json:"resource_type"`
+	ResourcePath   string                     `json:"resource_path"`
+	AuthDetails    realmAdminEventAuthDetails `json:"auth_details"`
+	Error          string                     `json:"error"`
+	Representation string                     `json:"representation"`
+}
+
+// realmEventsPageSize is the number of events fetched per request when querying events.
+const realmEventsPageSize = 100
+
+// realmEventsDateFormat is the format of the dates that bound an events query, as the identity service
+// only filters events by day.
+const realmEventsDateFormat = "2006-01-02"
+
+// realmEventsWindow wraps the times events are queried between, either of which is unbounded when zero.
+type realmEventsWindow struct {
+	From time.Time
+	To   time.Time
+}
+
+// newRealmEventsWindow parses the RFC 3339 timestamps (if any) events are queried between,
+// returning the window and error (if any).
+func newRealmEventsWindow(from string, to string) (realmEventsWindow, error) {
+	var window realmEventsWindow
+	var err error
+
+	if from != "" {
+		window.From, err = time.Parse(time.RFC3339, from)
+		if err != nil {
+			return window, fmt.Errorf("invalid from %q: %s", from, err)
+		}
+	}
+	if to != "" {
+		window.To, err = time.Parse(time.RFC3339, to)
+		if err != nil {
+			return window, fmt.Errorf("invalid to %q: %s", to, err)
+		}
+	}
+	if !window.From.IsZero() && !window.To.IsZero() && window.To.Before(window.From) {
+		return window, fmt.Errorf("to %q is before from %q", to, from)
+	}
+
+	return window, nil
+}
+
+// query returns the query parameters for the UTC days the window spans.
+func (w realmEventsWindow) query() url.Values {
+	query := url.Values{}
+
+	if !w.From.IsZero() {
+		query.Set("date_from", w.From.UTC().Format(realmEventsDateFormat))
+	}
+	if !w.To.IsZero() {
+		query.Set("date_to", w.To.UTC().Format(realmEventsDateFormat))
+	}
+
+	return query
+}
+
+// contains returns whether an event time, in milliseconds since the epoch, is within the window.
+func (w realmEventsWindow) contains(milliseconds int64) bool {
+	eventTime := time.Unix(0, milliseconds*int64(time.Millisecond))
+
+	if !w.From.IsZero() && eventTime.Before(w.From) {
+		return false
+	}
+	return w.To.IsZero() || !eventTime.After(w.To)
+}
+
+// listRealmEvents fetches the events of the named realm within window matching query, following pages of events
+// until all matching events or maxResults events (if greater than 0) have been fetched, returning error (if any).
+func listRealmEvents(ctx context.Context, toznySDK *e3db.ToznySDKV3, realmName string, window realmEventsWindow, query url.Values, maxResults int) ([]realmEvent, error) {
+	var events []realmEvent
+
+	for first := 0; ; {
+		pageSize := realmEventsPageSize
+		if maxResults > 0 && maxResults-len(events) < pageSize {
+			pageSize = maxResults - len(events)
+		}
+		query.Set("first", strconv.Itoa(first))
+		query.Set("max", strconv.Itoa(pageSize))
+
+		var page []realmEvent
+		err := makeIdentityServiceCall(ctx, toznySDK, http.MethodGet, fmt.Sprintf("/v1/identity/realm/%s/events?%s", url.PathEscape(realmName), query.Encode()), nil, &page)
+		if err != nil {
+			return nil, err
+		}
+
+		first += len(page)
+		// The query only narrows events down to whole days, drop those outside the window on the first and last day
+		for _, event := range page {
+			if window.contains(event.Time) {
+				events = append(events, event)
+			}
+		}
+		if len(page) < pageSize || (maxResults > 0 && len(events) >= maxResults) {
+			return events, nil
+		}
+	}
+}
+
+// listRealmAdminEvents fetches the admin events of the named realm within window matching query, following pages of events
+// until all matching events or maxResults events (if greater than 0) have been fetched, returning error (if any).
+func listRealmAdminEvents(ctx context.Context, toznySDK *e3db.ToznySDKV3, realmName string, window realmEventsWindow, query url.Values, maxResults int) ([]realmAdminEvent, error) {
+	var adminEvents []realmAdminEvent
+
+	for first := 0; ; {
+		pageSize := realmEventsPageSize
+		if maxResults > 0 && maxResults-len(adminEvents) < pageSize {
+			pageSize = maxResults - len(adminEvents)
+		}
+		query.Set("first", strconv.Itoa(first))
+		query.Set("max", strconv.Itoa(pageSize))
+
+		var page []realmAdminEvent
+		err := makeIdentityServiceCall(ctx, toznySDK, http.MethodGet, fmt.Sprintf("/v1/identity/realm/%s/admin-events?%s", url.PathEscape(realmName), query.Encode()), nil, &page)
+		if err != nil {
+			return nil, err
+		}
+
+		first += len(page)
+		for _, adminEvent := range page {
+			if window.contains(adminEvent.Time) {
+				adminEvents = append(adminEvents, adminEvent)
+			}
+		}
+		if len(page) < pageSize || (maxResults > 0 && len(adminEvents) >= maxResults) {
+			return adminEvents, nil
+		}
+	}
+}
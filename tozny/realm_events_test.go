@@ -0,0 +1,85 @@
+package tozny
+
+import (
+	"testing"
+	"time"
+)
+
+func TestNewRealmEventsWindowQuery(t *testing.T) {
+	cases := []struct {
+		from     string
+		to       string
+		dateFrom string
+		dateTo   string
+	}{
+		{"", "", "", ""},
+		{"2021-03-04T10:00:00Z", "", "2021-03-04", ""},
+		{"", "2021-03-05T23:59:59Z", "", "2021-03-05"},
+		{"2021-03-04T10:00:00Z", "2021-03-05T01:30:00Z", "2021-03-04", "2021-03-05"},
+		// Offsets are converted to the UTC day the event times are kept in
+		{"2021-03-04T22:00:00-05:00", "2021-03-06T01:30:00+02:00", "2021-03-05", "2021-03-05"},
+	}
+
+	for _, c := range cases {
+		window, err := newRealmEventsWindow(c.from, c.to)
+		if err != nil {
+			t.Fatalf("newRealmEventsWindow(%q, %q) returned error %s", c.from, c.to, err)
+		}
+		query := window.query()
+		if dateFrom := query.Get("date_from"); dateFrom != c.dateFrom {
+			t.Errorf("newRealmEventsWindow(%q, %q) queried date_from %q, expected %q", c.from, c.to, dateFrom, c.dateFrom)
+		}
+		if dateTo := query.Get("date_to"); dateTo != c.dateTo {
+			t.Errorf("newRealmEventsWindow(%q, %q) queried date_to %q, expected %q", c.from, c.to, dateTo, c.dateTo)
+		}
+	}
+}
+
+func TestNewRealmEventsWindowInvalid(t *testing.T) {
+	cases := []struct {
+		from string
+		to   string
+	}{
+		{"2021-03-04", ""},
+		{"", "yesterday"},
+		{"2021-03-05T00:00:00Z", "2021-03-04T23:59:59Z"},
+	}
+
+	for _, c := range cases {
+		if _, err := newRealmEventsWindow(c.from, c.to); err == nil {
+			t.Errorf("expected newRealmEventsWindow(%q, %q) to return an error", c.from, c.to)
+		}
+	}
+}
+
+func TestRealmEventsWindowContains(t *testing.T) {
+	window, err := newRealmEventsWindow("2021-03-04T10:00:00Z", "2021-03-05T01:30:00Z")
+	if err != nil {
+		t.Fatalf("newRealmEventsWindow returned error %s", err)
+	}
+
+	cases := map[string]bool{
+		"2021-03-04T00:00:00Z":     false,
+		"2021-03-04T09:59:59.999Z": false,
+		"2021-03-04T10:00:00Z":     true,
+		"2021-03-04T18:00:00Z":     true,
+		"2021-03-05T01:30:00Z":     true,
+		"2021-03-05T01:30:00.001Z": false,
+		"2021-03-05T23:00:00Z":     false,
+	}
+
+	for eventTime, expected := range cases {
+		parsed, err := time.Parse(time.RFC3339, eventTime)
+		if err != nil {
+			t.Fatalf("unable to parse %q: %s", eventTime, err)
+		}
+		if contains := window.contains(parsed.UnixNano() / int64(time.Millisecond)); contains != expected {
+			t.Errorf("contains(%s) = %t, expected %t", eventTime, contains, expected)
+		}
+	}
+
+	var unbounded realmEventsWindow
+	if !unbounded.contains(0) {
+		t.Error("expected a window without from or to to contain every event")
+	}
+}
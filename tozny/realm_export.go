@@ -0,0 +1,341 @@
+package tozny
+
+import (
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+
+	"github.com/tozny/e3db-clients-go/identityClient"
+	"github.com/tozny/e3db-go/v2"
+)
+
+const (
+	// realmExportVersion is the version of the realm export document format, incremented
+	// whenever the document changes in a way readers need to account for.
+	realmExportVersion = 1
+	// encryptedRealmExportSecretPrefix prefixes secrets encrypted in a realm export document.
+	encryptedRealmExportSecretPrefix = "aes256gcm:"
+)
+
+// realmExport wraps the configuration of a realm exported as a single document.
+type realmExport struct {
+	Version           int                    `json:"version"`
+	Realm             map[string]interface{} `json:"realm"`
+	Settings          map[string]interface{} `json:"settings"`
+	Applications      []interface{}          `json:"applications"`
+	Roles             []interface{}          `json:"roles"`
+	Groups            []interface{}          `json:"groups"`
+	DefaultGroups     []interface{}          `json:"default_groups"`
+	AccessPolicies    []interface{}          `json:"access_policies"`
+	Providers         []interface{}          `json:"providers"`
+	IdentityProviders []interface{}          `json:"identity_providers"`
+}
+
+// realmExportSecretKeys are the normalized names of exported fields that hold secrets.
+var realmExportSecretKeys = map[string]bool{
+	"secret":         true,
+	"clientsecret":   true,
+	"bindcredential": true,
+	"password":       true,
+	"privatekey":     true,
+}
+
+// exportRealm walks the configuration of the named realm, returning the export and error (if any).
+func exportRealm(ctx context.Context, toznySDK *e3db.ToznySDKV3, realmName string) (*realmExport, error) {
+	export := realmExport{
+		Version:        realmExportVersion,
+		Applications:   []interface{}{},
+		Groups:         []interface{}{},
+		AccessPolicies: []interface{}{},
+	}
+
+	realm, err := toznySDK.DescribeRealm(ctx, realmName)
+	if err != nil {
+		return nil, err
+	}
+	if err = toRealmExportValue(realm, &export.Realm); err != nil {
+		return nil, err
+	}
+
+	settings, err := describeRealmSettings(ctx, toznySDK, realmName)
+	if err != nil {
+		return nil, err
+	}
+	if err = toRealmExportValue(settings, &export.Settings); err != nil {
+		return nil, err
+	}
+
+	applications, err := toznySDK.ListRealmApplications(ctx, realmName)
+	if err != nil {
+		return nil, err
+	}
+	for _, application := range applications.Applications {
+		var exportedApplication map[string]interface{}
+		if err = toRealmExportValue(application, &exportedApplication); err != nil {
+			return nil, err
+		}
+		applicationPath := fmt.Sprintf("%s/application/%s", realmPath(realmName), url.PathEscape(application.ID))
+		exportedApplication["mappers"], err = listRealmExportItems(ctx, toznySDK, applicationPath+"/mapper", "application_mappers")
+		if err != nil {
+			return nil, err
+		}
+		exportedApplication["roles"], err = listRealmExportItems(ctx, toznySDK, applicationPath+"/role", "application_roles")
+		if err != nil {
+			return nil, err
+		}
+		sortRealmExportItems(exportedApplication["mappers"].([]interface{}))
+		sortRealmExportItems(exportedApplication["roles"].([]interface{}))
+		export.Applications = append(export.Applications, exportedApplication)
+	}
+
+	roles, err := toznySDK.ListRealmRoles(ctx, realmName)
+	if err != nil {
+		return nil, err
+	}
+	if err = toRealmExportValue(roles.Roles, &export.Roles); err != nil {
+		return nil, err
+	}
+
+	groups, err := toznySDK.ListRealmGroups(ctx, identityClient.ListRealmGroupsRequest{
+		RealmName: realmName,
+	})
+	if err != nil {
+		return nil, err
+	}
+	groupIDs := []string{}
+	for _, group := range groups.Groups {
+		var exportedGroup map[string]interface{}
+		if err = toRealmExportValue(group, &exportedGroup); err != nil {
+			return nil, err
+		}
+		groupIDs = append(groupIDs, group.ID)
+		roleMappings, err := toznySDK.ListGroupRoleMappings(ctx, identityClient.ListGroupRoleMappingsRequest{
+			RealmName: strings.ToLower(realmName),
+			GroupID:   group.ID,
+		})
+		if err != nil {
+			return nil, err
+		}
+		var exportedRoleMappings interface{}
+		if err = toRealmExportValue(roleMappings, &exportedRoleMappings); err != nil {
+			return nil, err
+		}
+		sortRealmExportRoleMappings(exportedRoleMappings)
+		exportedGroup["role_mappings"] = exportedRoleMappings
+		export.Groups = append(export.Groups, exportedGroup)
+	}
+
+	defaultGroups, err := toznySDK.ListRealmDefaultGroups(ctx, identityClient.ListRealmGroupsRequest{
+		RealmName: realmName,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if err = toRealmExportValue(defaultGroups.Groups, &export.DefaultGroups); err != nil {
+		return nil, err
+	}
+
+	if len(groupIDs) > 0 {
+		accessPolicies, err := toznySDK.ListAccessPolicies(ctx, identityClient.ListAccessPoliciesRequest{
+			RealmName: realmName,
+			GroupIDs:  groupIDs,
+		})
+		if err != nil {
+			return nil, err
+		}
+		// Order policies by group, and each group's policies and approval roles by ID
+		groupAccessPolicies := accessPolicies.GroupAccessPolicies
+		sort.SliceStable(groupAccessPolicies, func(i, j int) bool {
+			return groupAccessPolicies[i].GroupID < groupAccessPolicies[j].GroupID
+		})
+		for _, groupAccessPolicy := range groupAccessPolicies {
+			policies := groupAccessPolicy.AccessPolicies
+			sort.SliceStable(policies, func(i, j int) bool {
+				return fmt.Sprint(policies[i].ID) < fmt.Sprint(policies[j].ID)
+			})
+			for _, policy := range policies {
+				approvalRoles := policy.ApprovalRoles
+				sort.SliceStable(approvalRoles, func(i, j int) bool {
+					return approvalRoles[i].ID < approvalRoles[j].ID
+				})
+			}
+		}
+		if err = toRealmExportValue(groupAccessPolicies, &export.AccessPolicies); err != nil {
+			return nil, err
+		}
+	}
+
+	export.Providers, err = listRealmExportItems(ctx, toznySDK, realmPath(realmName)+"/provider", "providers")
+	if err != nil {
+		return nil, err
+	}
+	for _, provider := range export.Providers {
+		provider := provider.(map[string]interface{})
+		providerMappers, err := listRealmExportItems(ctx, toznySDK, fmt.Sprintf("%s/provider/%s/mapper", realmPath(realmName), url.PathEscape(fmt.Sprint(provider["id"]))), "provider_mappers")
+		if err != nil {
+			return nil, err
+		}
+		sortRealmExportItems(providerMappers)
+		provider["mappers"] = providerMappers
+	}
+
+	export.IdentityProviders, err = listRealmExportItems(ctx, toznySDK, realmPath(realmName)+"/identity-provider", "identity_providers")
+	if err != nil {
+		return nil, err
+	}
+
+	// Order items by name so exports of the same configuration are identical
+	for _, items := range [][]interface{}{export.Applications, export.Roles, export.Groups, export.DefaultGroups, export.Providers, export.IdentityProviders} {
+		sortRealmExportItems(items)
+	}
+
+	return &export, nil
+}
+
+// listRealmExportItems lists the objects at an identity service path for items the Tozny SDK can't list,
+// which are either returned directly or wrapped under the given key, returning the items and error (if any).
+// Responses of any other shape are an error, so an export is never silently missing items.
+func listRealmExportItems(ctx context.Context, toznySDK *e3db.ToznySDKV3, path string, wrapperKey string) ([]interface{}, error) {
+	var result interface{}
+
+	err := makeIdentityServiceCall(ctx, toznySDK, http.MethodGet, path, nil, &result)
+	if err != nil {
+		return nil, err
+	}
+
+	if wrapper, ok := result.(map[string]interface{}); ok {
+		if result, ok = wrapper[wrapperKey]; !ok {
+			return nil, fmt.Errorf("unexpected response listing %s: no %q in response", path, wrapperKey)
+		}
+	}
+
+	switch items := result.(type) {
+	case nil:
+		// Empty lists may be returned as null
+		return []interface{}{}, nil
+	case []interface{}:
+		for _, item := range items {
+			if _, ok := item.(map[string]interface{}); !ok {
+				return nil, fmt.Errorf("unexpected response listing %s: item %v is not an object", path, item)
+			}
+		}
+		return items, nil
+	default:
+		return nil, fmt.Errorf("unexpected response listing %s: %v is not a list", path, items)
+	}
+}
+
+// toRealmExportValue converts a value to its generic JSON representation for export, returning error (if any).
+func toRealmExportValue(value interface{}, exportValue interface{}) error {
+	serialized, err := json.Marshal(value)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(serialized, exportValue)
+}
+
+// realmExportItemName returns the name, alias or client ID of an exported item, falling back to its ID.
+func realmExportItemName(item interface{}) string {
+	fields, ok := item.(map[string]interface{})
+	if !ok {
+		return ""
+	}
+	for _, key := range []string{"name", "alias", "client_id", "clientId", "id"} {
+		if value, ok := fields[key]; ok && value != nil {
+			return fmt.Sprint(value)
+		}
+	}
+	return ""
+}
+
+// sortRealmExportItems orders exported items by their name, alias or client ID, falling back to their ID.
+func sortRealmExportItems(items []interface{}) {
+	sort.SliceStable(items, func(i, j int) bool {
+		return realmExportItemName(items[i]) < realmExportItemName(items[j])
+	})
+}
+
+// sortRealmExportRoleMappings orders the lists of roles in exported role mappings, whether they are
+// listed directly or grouped by realm and application, with sortRealmExportItems.
+func sortRealmExportRoleMappings(roleMappings interface{}) {
+	switch typedRoleMappings := roleMappings.(type) {
+	case []interface{}:
+		sortRealmExportItems(typedRoleMappings)
+	case map[string]interface{}:
+		for _, roles := range typedRoleMappings {
+			sortRealmExportRoleMappings(roles)
+		}
+	}
+}
+
+// protectRealmExportSecrets walks an exported value removing any secrets or, if an encryption key is
+// provided, replacing them with their encryption under the key, returning the protected value and error (if any).
+func protectRealmExportSecrets(value interface{}, encryptionKey []byte) (interface{}, error) {
+	switch typedValue := value.(type) {
+	case map[string]interface{}:
+		for key, field := range typedValue {
+			normalizedKey := strings.ToLower(strings.NewReplacer("_", "", "-", "").Replace(key))
+			if !realmExportSecretKeys[normalizedKey] {
+				protectedField, err := protectRealmExportSecrets(field, encryptionKey)
+				if err != nil {
+					return nil, err
+				}
+				typedValue[key] = protectedField
+				continue
+			}
+			if encryptionKey == nil || field == nil || field == "" {
+				delete(typedValue, key)
+				continue
+			}
+			encryptedField, err := encryptRealmExportSecret(field, encryptionKey)
+			if err != nil {
+				return nil, err
+			}
+			typedValue[key] = encryptedField
+		}
+	case []interface{}:
+		for i, item := range typedValue {
+			protectedItem, err := protectRealmExportSecrets(item, encryptionKey)
+			if err != nil {
+				return nil, err
+			}
+			typedValue[i] = protectedItem
+		}
+	}
+	return value, nil
+}
+
+// encryptRealmExportSecret encrypts the JSON representation of a secret with AES-256-GCM,
+// returning the prefixed, base64 encoded nonce and ciphertext and error (if any).
+func encryptRealmExportSecret(secret interface{}, encryptionKey []byte) (string, error) {
+	plaintext, err := json.Marshal(secret)
+	if err != nil {
+		return "", err
+	}
+
+	block, err := aes.NewCipher(encryptionKey)
+	if err != nil {
+		return "", err
+	}
+
+	aead, err := cipher.NewGCM(block)
+	if err != nil {
+		return "", err
+	}
+
+	nonce := make([]byte, aead.NonceSize())
+	if _, err = io.ReadFull(rand.Reader, nonce); err != nil {
+		return "", err
+	}
+
+	return encryptedRealmExportSecretPrefix + base64.StdEncoding.EncodeToString(aead.Seal(nonce, nonce, plaintext, nil)), nil
+}
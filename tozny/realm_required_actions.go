@@ -0,0 +1,43 @@
+package tozny
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+
+	"github.com/tozny/e3db-go/v2"
+)
+
+// realmRequiredAction wraps an action identities of a realm can be required to complete when logging in.
+type realmRequiredAction struct {
+	Alias         string            `json:"alias"`
+	Name          string            `json:"name"`
+	ProviderID    string            `json:"provider_id"`
+	Enabled       bool              `json:"enabled"`
+	DefaultAction bool              `json:"default_action"`
+	Priority      int               `json:"priority"`
+	Config        map[string]string `json:"config,omitempty"`
+}
+
+// realmRequiredActionPath returns the identity service path for the named realm's required action with the given alias.
+func realmRequiredActionPath(realmName string, alias string) string {
+	return fmt.Sprintf("/v1/identity/realm/%s/required-actions/%s", url.PathEscape(realmName), url.PathEscape(alias))
+}
+
+// describeRealmRequiredAction fetches the required action with the given alias from the named realm, returning error (if any).
+func describeRealmRequiredAction(ctx context.Context, toznySDK *e3db.ToznySDKV3, realmName string, alias string) (*realmRequiredAction, error) {
+	var requiredAction realmRequiredAction
+
+	err := makeIdentityServiceCall(ctx, toznySDK, http.MethodGet, realmRequiredActionPath(realmName, alias), nil, &requiredAction)
+	if err != nil {
+		return nil, err
+	}
+
+	return &requiredAction, nil
+}
+
+// updateRealmRequiredAction replaces the required action with the given alias in the named realm, returning error (if any).
+func updateRealmRequiredAction(ctx context.Context, toznySDK *e3db.ToznySDKV3, realmName string, requiredAction realmRequiredAction) error {
+	return makeIdentityServiceCall(ctx, toznySDK, http.MethodPut, realmRequiredActionPath(realmName, requiredAction.Alias), requiredAction, nil)
+}
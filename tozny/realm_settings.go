@@ -0,0 +1,156 @@
+package tozny
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"reflect"
+
+	"github.com/tozny/e3db-go/v2"
+)
+
+// realmSettings wraps the administrative settings of a realm that are not currently
+// surfaced by the Tozny SDK. Fields left nil are not changed when updating a realm.
+//
+// The SDK only sends the handful of settings in identityClient's RealmSettingsUpdateRequest, such as
+// mpc_enabled, and can't read settings back, so these are read from and written to the identity service's
+// realm settings endpoint directly until the SDK wraps them. Identity services that don't serve the endpoint
+// answer with not found, so nothing is sent when no settings are set, and realms are still read without them.
+type realmSettings struct {
+	PasswordPolicy *string `json:"password_policy,omitempty"`
+	// Session and token lifetimes, in seconds
+	SSOSessionIdleTimeout            *int  `json:"sso_session_idle_timeout,omitempty"`
+	SSOSessionMaxLifespan            *int  `json:"sso_session_max_lifespan,omitempty"`
+	AccessTokenLifespan              *int  `json:"access_token_lifespan,omitempty"`
+	RevokeRefreshToken               *bool `json:"revoke_refresh_token,omitempty"`
+	RefreshTokenMaxReuse             *int  `json:"refresh_token_max_reuse,omitempty"`
+	OfflineSessionIdleTimeout        *int  `json:"offline_session_idle_timeout,omitempty"`
+	OfflineSessionMaxLifespanEnabled *bool `json:"offline_session_max_lifespan_enabled,omitempty"`
+	OfflineSessionMaxLifespan        *int  `json:"offline_session_max_lifespan,omitempty"`
+	LoginTimeout                     *int  `json:"login_timeout,omitempty"`
+	LoginActionTimeout               *int  `json:"login_action_timeout,omitempty"`
+	// Brute force detection, wait times in seconds
+	BruteForceProtected          *bool `json:"brute_force_protected,omitempty"`
+	FailureFactor                *int  `json:"failure_factor,omitempty"`
+	WaitIncrementSeconds         *int  `json:"wait_increment_seconds,omitempty"`
+	MaxFailureWaitSeconds        *int  `json:"max_failure_wait_seconds,omitempty"`
+	QuickLoginCheckMilliSeconds  *int  `json:"quick_login_check_milli_seconds,omitempty"`
+	MinimumQuickLoginWaitSeconds *int  `json:"minimum_quick_login_wait_seconds,omitempty"`
+	MaxDeltaTimeSeconds          *int  `json:"max_delta_time_seconds,omitempty"`
+	PermanentLockout             *bool `json:"permanent_lockout,omitempty"`
+	// One time password policy
+	OTPPolicyType            *string `json:"otp_policy_type,omitempty"`
+	OTPPolicyAlgorithm       *string `json:"otp_policy_algorithm,omitempty"`
+	OTPPolicyDigits          *int    `json:"otp_policy_digits,omitempty"`
+	OTPPolicyPeriod          *int    `json:"otp_policy_period,omitempty"`
+	OTPPolicyLookAheadWindow *int    `json:"otp_policy_look_ahead_window,omitempty"`
+	OTPPolicyInitialCounter  *int    `json:"otp_policy_initial_counter,omitempty"`
+	// WebAuthn policy
+	WebAuthnPolicyRPEntityName                    *string `json:"webauthn_policy_rp_entity_name,omitempty"`
+	WebAuthnPolicyRPID                            *string `json:"webauthn_policy_rp_id,omitempty"`
+	WebAuthnPolicyAttestationConveyancePreference *string `json:"webauthn_policy_attestation_conveyance_preference,omitempty"`
+	WebAuthnPolicyAuthenticatorAttachment         *string `json:"webauthn_policy_authenticator_attachment,omitempty"`
+	WebAuthnPolicyUserVerificationRequirement     *string `json:"webauthn_policy_user_verification_requirement,omitempty"`
+	// Email delivery, keyed by setting name and template name respectively
+	SMTPServer     *map[string]string             `json:"smtp_server,omitempty"`
+	EmailTemplates *map[string]realmEmailTemplate `json:"email_templates,omitempty"`
+	// Themes and branding
+	LoginTheme      *string `json:"login_theme,omitempty"`
+	AccountTheme    *string `json:"account_theme,omitempty"`
+	EmailTheme      *string `json:"email_theme,omitempty"`
+	DisplayName     *string `json:"display_name,omitempty"`
+	DisplayNameHTML *string `json:"display_name_html,omitempty"`
+	// Localization
+	InternationalizationEnabled *bool     `json:"internationalization_enabled,omitempty"`
+	SupportedLocales            *[]string `json:"supported_locales,omitempty"`
+	DefaultLocale               *string   `json:"default_locale,omitempty"`
+	// Self-registration and login options
+	RegistrationAllowed         *bool `json:"registration_allowed,omitempty"`
+	RegistrationEmailAsUsername *bool `json:"registration_email_as_username,omitempty"`
+	VerifyEmail                 *bool `json:"verify_email,omitempty"`
+	LoginWithEmailAllowed       *bool `json:"login_with_email_allowed,omitempty"`
+	DuplicateEmailsAllowed      *bool `json:"duplicate_emails_allowed,omitempty"`
+	RememberMe                  *bool `json:"remember_me,omitempty"`
+	ResetPasswordAllowed        *bool `json:"reset_password_allowed,omitempty"`
+	// Authentication flow bindings, by flow alias
+	BrowserFlow              *string `json:"browser_flow,omitempty"`
+	DirectGrantFlow          *string `json:"direct_grant_flow,omitempty"`
+	RegistrationFlow         *string `json:"registration_flow,omitempty"`
+	ResetCredentialsFlow     *string `json:"reset_credentials_flow,omitempty"`
+	ClientAuthenticationFlow *string `json:"client_authentication_flow,omitempty"`
+	DockerAuthenticationFlow *string `json:"docker_authentication_flow,omitempty"`
+}
+
+// realmUpdate wraps the properties of a realm that can be changed in place.
+// Fields left nil are not changed when updating a realm.
+type realmUpdate struct {
+	Name   *string `json:"name,omitempty"`
+	Active *bool   `json:"active,omitempty"`
+}
+
+// realmEmailTemplate wraps an override of the subject and body of an email sent by a realm.
+type realmEmailTemplate struct {
+	Subject  string `json:"subject"`
+	TextBody string `json:"text_body"`
+	HTMLBody string `json:"html_body"`
+}
+
+// realmPath returns the identity service path for the named realm.
+func realmPath(realmName string) string {
+	return fmt.Sprintf("/v1/identity/realm/%s", url.PathEscape(realmName))
+}
+
+// updateRealm applies the non nil properties to the named realm, returning error (if any).
+func updateRealm(ctx context.Context, toznySDK *e3db.ToznySDKV3, realmName string, update realmUpdate) error {
+	return makeIdentityServiceCall(ctx, toznySDK, http.MethodPatch, realmPath(realmName), update, nil)
+}
+
+// realmSettingsPath returns the identity service path for the settings of the named realm.
+func realmSettingsPath(realmName string) string {
+	return fmt.Sprintf("%s/settings", realmPath(realmName))
+}
+
+// describeRealmSettings fetches the administrative settings of the named realm, returning error (if any).
+func describeRealmSettings(ctx context.Context, toznySDK *e3db.ToznySDKV3, realmName string) (*realmSettings, error) {
+	var settings realmSettings
+
+	err := makeIdentityServiceCall(ctx, toznySDK, http.MethodGet, realmSettingsPath(realmName), nil, &settings)
+	if err != nil {
+		return nil, err
+	}
+
+	return &settings, nil
+}
+
+// updateRealmSettings applies the non nil administrative settings to the named realm, returning error (if any).
+func updateRealmSettings(ctx context.Context, toznySDK *e3db.ToznySDKV3, realmName string, settings realmSettings) error {
+	if reflect.DeepEqual(settings, realmSettings{}) {
+		return nil
+	}
+	return makeIdentityServiceCall(ctx, toznySDK, http.MethodPatch, realmSettingsPath(realmName), settings, nil)
+}
+
+// intSetting returns the value of an optional integer realm setting, or 0 if unset.
+func intSetting(setting *int) int {
+	if setting == nil {
+		return 0
+	}
+	return *setting
+}
+
+// stringSetting returns the value of an optional string realm setting, or "" if unset.
+func stringSetting(setting *string) string {
+	if setting == nil {
+		return ""
+	}
+	return *setting
+}
+
+// boolSetting returns the value of an optional boolean realm setting, or false if unset.
+func boolSetting(setting *bool) bool {
+	if setting == nil {
+		return false
+	}
+	return *setting
+}
@@ -0,0 +1,82 @@
+package tozny
+
+import (
+	"context"
+	"strconv"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/tozny/e3db-go/v2"
+)
+
+// realmSettingsApplyFunc applies the part of the settings of the named realm managed by a resource, returning error (if any).
+type realmSettingsApplyFunc func(ctx context.Context, toznySDK *e3db.ToznySDKV3, d *schema.ResourceData, realmName string) error
+
+// createOrUpdateRealmSettingsResource creates or updates a resource that manages part of the settings
+// of an existing realm with apply, identifying the resource by the ID of its realm, then reads it back with read.
+func createOrUpdateRealmSettingsResource(ctx context.Context, d *schema.ResourceData, m interface{}, apply realmSettingsApplyFunc, read schema.ReadContextFunc) diag.Diagnostics {
+	toznySDK, err := MakeToznySDK(d, m)
+
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	// Only renames of the realm are updated in place, pointing the resource at a different realm replaces it
+	err = CheckRealmRenamed(ctx, toznySDK, d)
+
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	if d.Get("realm_id").(int) == 0 {
+		realmExists, err := ReadRealmIntoState(ctx, toznySDK, d)
+
+		if err != nil {
+			return diag.FromErr(err)
+		}
+
+		if !realmExists {
+			return diag.Errorf("realm %q not found", d.Get("realm_name").(string))
+		}
+	}
+
+	err = apply(ctx, toznySDK, d, d.Get("realm_name").(string))
+
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(realmSettingsResourceID(d))
+
+	return read(ctx, d, m)
+}
+
+// readRealmSettingsResourceRealm looks up the realm of a resource that manages part of its settings by ID,
+// so the resource can still be read after the realm is renamed, and identifies the resource by that ID.
+// It returns whether the realm exists and error (if any).
+func readRealmSettingsResourceRealm(ctx context.Context, toznySDK *e3db.ToznySDKV3, d *schema.ResourceData) (bool, error) {
+	realmExists, err := ReadRealmIntoState(ctx, toznySDK, d)
+	if err != nil || !realmExists {
+		return realmExists, err
+	}
+
+	// Resources identified by realm name before realms could be renamed switch to the realm ID when read
+	d.SetId(realmSettingsResourceID(d))
+
+	return true, nil
+}
+
+// importRealmSettingsResource imports a resource that manages part of the settings of the realm named by the import ID.
+func importRealmSettingsResource(ctx context.Context, d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+	d.Set("realm_name", d.Id())
+	d.Set("client_credentials_filepath", "")
+	d.Set("client_credentials_config", "")
+
+	return []*schema.ResourceData{d}, nil
+}
+
+// realmSettingsResourceID returns the ID of a resource that manages part of the settings of a realm,
+// which is the ID of the realm as it stays the same when the realm is renamed.
+func realmSettingsResourceID(d *schema.ResourceData) string {
+	return strconv.Itoa(d.Get("realm_id").(int))
+}
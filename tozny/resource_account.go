@@ -131,6 +131,7 @@ func resourceAccount() *schema.Resource {
 	return &schema.Resource{
 		CreateContext: resourceAccountCreate,
 		ReadContext:   resourceAccountRead,
+		UpdateContext: resourceAccountRead,
 		DeleteContext: resourceAccountDelete,
 		Schema: map[string]*schema.Schema{
 			"persist_credentials_to": {
@@ -139,7 +140,36 @@ func resourceAccount() *schema.Resource {
 				Default:      "none",
 				Optional:     true,
 				ForceNew:     true,
-				ValidateFunc: validation.StringInSlice([]string{"none", "file", "terraform"}, false),
+				ValidateFunc: validation.StringInSlice([]string{"none", "file", "terraform", "tozstore"}, false),
+			},
+			"credentials_vault_client_credentials_filepath": {
+				Description:   "The filepath to Tozny client credentials for the client that will own the TozStore record the account credentials are persisted to when persist_credentials_to is set to 'tozstore'. One of this or credentials_vault_client_credentials_config is required in that case.",
+				Type:          schema.TypeString,
+				Optional:      true,
+				Default:       "",
+				ForceNew:      true,
+				ConflictsWith: []string{"credentials_vault_client_credentials_config"},
+			},
+			"credentials_vault_client_credentials_config": {
+				Description:   "The Tozny client configuration as a JSON string for the client that will own the TozStore record the account credentials are persisted to when persist_credentials_to is set to 'tozstore'. One of this or credentials_vault_client_credentials_filepath is required in that case.",
+				Type:          schema.TypeString,
+				Optional:      true,
+				Default:       "",
+				ForceNew:      true,
+				Sensitive:     true,
+				ConflictsWith: []string{"credentials_vault_client_credentials_filepath"},
+			},
+			"credentials_record_type": {
+				Description: "The type of the TozStore record the account credentials are persisted to when persist_credentials_to is set to 'tozstore'.",
+				Type:        schema.TypeString,
+				Optional:    true,
+				Default:     "tozny.terraform.account.credentials",
+				ForceNew:    true,
+			},
+			"credentials_record_id": {
+				Description: "ID of the TozStore record containing the account credentials. Only populated when persist_credentials_to is set to 'tozstore'.",
+				Type:        schema.TypeString,
+				Computed:    true,
 			},
 			"autogenerate_account_credentials": {
 				Description:   "Whether Terraform should generate credentials for a provisioned account.",
@@ -163,6 +193,12 @@ func resourceAccount() *schema.Resource {
 				Default:     "tozny_client_credentials.json",
 				ForceNew:    true,
 			},
+			"deletion_protection": {
+				Description: "Whether Terraform is prevented from destroying the account. Must be set to false in a prior apply before the account can be destroyed or replaced.",
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     true,
+			},
 			"config": {
 				Description: "The client configuration as a JSON string. Only populated when persist_credentails_to is set to 'terraform'",
 				Type:        schema.TypeString,
@@ -336,12 +372,22 @@ func resourceAccountCreate(ctx context.Context, d *schema.ResourceData, m interf
 
 	var accountID string
 
+	// Build the vault SDK before creating the account so bad vault credentials can't orphan it
+	var vaultSDK *e3db.ToznySDKV3
+	if persistTo == "tozstore" {
+		var err error
+		vaultSDK, err = MakeToznyVaultSDK(d.Get("credentials_vault_client_credentials_filepath").(string), d.Get("credentials_vault_client_credentials_config").(string))
+		if err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
 	if d.Get(autoGenerateKey).(bool) {
 		if accountCredentialsFilepath != "" {
 			return diag.Errorf("Only one of %s or %s can be specified", autoGenerateKey, credentialsFilepathKey)
 		}
 
-		if persistTo != "file" && persistTo != "terraform" {
+		if persistTo != "file" && persistTo != "terraform" && persistTo != "tozstore" {
 			return diag.Errorf("Can not auto-generate credentials if no persistance is defined in %q", persistKey)
 		} else if persistTo == "file" && saveFilepath == "" {
 			return diag.Errorf("%s must be supplied if %s is set to %q", saveFilepathKey, persistKey, "file")
@@ -487,6 +533,17 @@ func resourceAccountCreate(ctx context.Context, d *schema.ResourceData, m interf
 	case "terraform":
 		d.Set("config", string(clientCredentialsJSONBytes))
 		break
+	case "tozstore":
+		recordID, err := WriteToznyCredentialsRecord(ctx, vaultSDK, d.Get("credentials_record_type").(string), string(clientCredentialsJSONBytes))
+		if err != nil {
+			// Keep the created account and its credentials in state rather than losing both
+			d.Set("config", string(clientCredentialsJSONBytes))
+			d.SetId(accountID)
+			return diag.Errorf("Account %q was created but its credentials could not be written to TozStore and have been kept in the %q attribute instead: %+v", accountID, "config", err)
+		}
+		d.Set("credentials_record_id", recordID)
+		d.Set("config", "")
+		break
 	default:
 		d.Set("config", "")
 		break
@@ -510,6 +567,11 @@ func resourceAccountDelete(ctx context.Context, d *schema.ResourceData, m interf
 	var toznySDK *e3db.ToznySDKV3
 	var err error
 	var deleteAccountParams accountClient.DeleteAccountRequestData
+	var vaultSDK *e3db.ToznySDKV3
+
+	if protected := CheckDeletionProtection(d, "Account", d.Id()); protected != nil {
+		return protected
+	}
 
 	accountID := uuid.MustParse(d.Id())
 
@@ -532,39 +594,30 @@ func resourceAccountDelete(ctx context.Context, d *schema.ResourceData, m interf
 		if err != nil {
 			return diag.Errorf("Failed to unmarshal %+v", err)
 		}
-		sdkConfig := e3db.ToznySDKConfig{
-			ClientConfig: e3dbClients.ClientConfig{
-				ClientID:  config.ClientID,
-				APIKey:    config.APIKeyID,
-				APISecret: config.APISecret,
-				Host:      config.APIBaseURL,
-				AuthNHost: config.APIBaseURL,
-				SigningKeys: e3dbClients.SigningKeys{
-					Public: e3dbClients.Key{
-						Type:     e3dbClients.DefaultSigningKeyType,
-						Material: config.PublicSigningKey,
-					},
-					Private: e3dbClients.Key{
-						Type:     e3dbClients.DefaultSigningKeyType,
-						Material: config.PrivateSigningKey,
-					},
-				},
-				EncryptionKeys: e3dbClients.EncryptionKeys{
-					Private: e3dbClients.Key{
-						Material: config.PrivateKey,
-						Type:     e3dbClients.DefaultEncryptionKeyType,
-					},
-					Public: e3dbClients.Key{
-						Material: config.PublicKey,
-						Type:     e3dbClients.DefaultEncryptionKeyType,
-					},
-				},
-			},
-			AccountUsername: config.AccountUsername,
-			AccountPassword: config.AccountPassword,
-			APIEndpoint:     config.APIBaseURL,
+		toznySDK, err = NewToznySDKFromJSONConfig(config)
+		if err != nil {
+			return diag.Errorf(" SDK creation Failed %+v", err)
 		}
-		toznySDK, err = e3db.NewToznySDKV3(sdkConfig)
+		break
+	case "tozstore":
+		vaultSDK, err = MakeToznyVaultSDK(d.Get("credentials_vault_client_credentials_filepath").(string), d.Get("credentials_vault_client_credentials_config").(string))
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		// Credentials are only kept in config when they could not be written to TozStore
+		configJSON := d.Get("config").(string)
+		if recordID := d.Get("credentials_record_id").(string); recordID != "" {
+			configJSON, _, err = ReadToznyCredentialsRecord(ctx, vaultSDK, recordID)
+			if err != nil {
+				return diag.Errorf("Credentials not found %+v", err)
+			}
+		}
+		var config e3db.ToznySDKJSONConfig
+		err = json.Unmarshal([]byte(configJSON), &config)
+		if err != nil {
+			return diag.Errorf("Failed to unmarshal %+v", err)
+		}
+		toznySDK, err = NewToznySDKFromJSONConfig(config)
 		if err != nil {
 			return diag.Errorf(" SDK creation Failed %+v", err)
 		}
@@ -636,6 +689,14 @@ func resourceAccountDelete(ctx context.Context, d *schema.ResourceData, m interf
 	if err != nil {
 		return diag.FromErr(err)
 	}
+
+	// Don't leave the deleted account's credentials behind in TozStore
+	if recordID := d.Get("credentials_record_id").(string); persistTo == "tozstore" && recordID != "" {
+		err = DeleteToznyCredentialsRecord(ctx, vaultSDK, recordID)
+		if err != nil {
+			return diag.Errorf("Account %q was deleted but its credentials record %q could not be: %+v", d.Id(), recordID, err)
+		}
+	}
 	d.SetId("")
 	return diags
 }
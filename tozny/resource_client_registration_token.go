@@ -7,6 +7,7 @@ import (
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 	"github.com/tozny/e3db-clients-go/accountClient"
 )
 
@@ -16,6 +17,10 @@ func resourceClientRegistrationToken() *schema.Resource {
 		CreateContext: resourceClientRegistrationTokenCreate,
 		ReadContext:   resourceClientRegistrationTokenRead,
 		DeleteContext: resourceClientRegistrationTokenDelete,
+		CustomizeDiff: resourceClientRegistrationTokenCustomizeDiff,
+		Importer: &schema.ResourceImporter{
+			StateContext: resourceClientRegistrationTokenImport,
+		},
 		Schema: map[string]*schema.Schema{
 			"name": {
 				Description: "User defined identifier for the token.",
@@ -27,11 +32,10 @@ func resourceClientRegistrationToken() *schema.Resource {
 				Description: "The client types that can be registered using the token. Valid types are `general`, `identity`, and `broker`",
 				Type:        schema.TypeList,
 				Required:    true,
-				ForceNew:    true,
 				MinItems:    1,
+				ForceNew:    true,
 				Elem: &schema.Schema{
-					Type:     schema.TypeString,
-					ForceNew: true,
+					Type: schema.TypeString,
 				},
 			},
 			"enabled": {
@@ -48,6 +52,37 @@ func resourceClientRegistrationToken() *schema.Resource {
 				Default:     false,
 				ForceNew:    true,
 			},
+			"max_uses": {
+				Description:   "The maximum number of clients that can be registered using the token. Defaults to `0`, which places no limit on uses.",
+				Type:          schema.TypeInt,
+				Optional:      true,
+				Default:       0,
+				ForceNew:      true,
+				ValidateFunc:  validation.IntAtLeast(0),
+				ConflictsWith: []string{"one_time_use"},
+			},
+			"expires_at": {
+				Description:  "RFC3339 timestamp after which Terraform considers the token consumed and replaces it on the next apply. The account service does not support token expiry, so the token can still register clients until it is replaced.",
+				Type:         schema.TypeString,
+				Optional:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.IsRFC3339Time,
+			},
+			"uses": {
+				Description: "The number of clients that have been registered using the token.",
+				Type:        schema.TypeInt,
+				Computed:    true,
+			},
+			"uses_remaining": {
+				Description: "The number of clients that can still be registered using the token, or `-1` if the token has no limit on uses.",
+				Type:        schema.TypeInt,
+				Computed:    true,
+			},
+			"consumed": {
+				Description: "Whether the token has been used up, has expired or no longer exists, in which case a new token will be created on the next apply.",
+				Type:        schema.TypeBool,
+				Computed:    true,
+			},
 			"client_credentials_filepath": {
 				Description:   "The filepath to Tozny client credentials for the provider to use when provisioning this registration token.",
 				Type:          schema.TypeString,
@@ -87,20 +122,28 @@ func resourceClientRegistrationTokenCreate(ctx context.Context, d *schema.Resour
 	}
 
 	tokenName := d.Get("name").(string)
-	tfAllowedTypes := d.Get("allowed_registration_client_types").([]interface{})
-	allowedTypes := []string{}
 
-	for _, tfAllowedType := range tfAllowedTypes {
-		allowedTypes = append(allowedTypes, tfAllowedType.(string))
+	if expiresAt := d.Get("expires_at").(string); expiresAt != "" {
+		expiry, err := time.Parse(time.RFC3339, expiresAt)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		if !expiry.After(time.Now()) {
+			return diag.Errorf("Can not create registration token %q with %q in the past", tokenName, "expires_at")
+		}
 	}
 
+	allowedTypes := SchemaToStringSlice(d.Get("allowed_registration_client_types").([]interface{}))
+
 	createTokenResponse, err := toznySDK.CreateRegistrationToken(ctx, accountClient.CreateRegistrationTokenRequest{
 		AccountServiceToken: account.Token,
 		Name:                tokenName,
 		TokenPermissions: accountClient.TokenPermissions{
 			Enabled:      d.Get("enabled").(bool),
+			OneTime:      d.Get("one_time_use").(bool),
 			AllowedTypes: allowedTypes,
 		},
+		TotalUsesAllowed: d.Get("max_uses").(int),
 	})
 
 	if err != nil {
@@ -110,9 +153,12 @@ func resourceClientRegistrationTokenCreate(ctx context.Context, d *schema.Resour
 	clientRegistrationToken := createTokenResponse.Token
 
 	d.Set("token", clientRegistrationToken)
+	d.Set("uses", 0)
+	d.Set("uses_remaining", registrationTokenUsesRemaining(d.Get("one_time_use").(bool), d.Get("max_uses").(int), 0))
+	d.Set("consumed", false)
 
-	// Associate created token with Terraform state and signal success
-	d.SetId(fmt.Sprintf("%d%v", time.Now().Unix(), tokenName))
+	// Associate created token with Terraform state
+	d.SetId(clientRegistrationToken)
 
 	return diags
 }
@@ -133,24 +179,42 @@ func resourceClientRegistrationTokenRead(ctx context.Context, d *schema.Resource
 		return diag.FromErr(err)
 	}
 
+	// Tokens are tracked by the token stored in state, as several tokens can share a name
+	token := d.Get("token").(string)
+
 	var listed bool
 
 	for _, listedRegistrationToken := range *listedRegistrationTokens {
-		if listedRegistrationToken.Name == d.Get("name").(string) {
-			if listedRegistrationToken.Token == d.Get("token").(string) {
-				listed = true
+		if listedRegistrationToken.Token == token {
+			listed = true
+
+			usesRemaining := registrationTokenUsesRemaining(listedRegistrationToken.Permissions.OneTime, listedRegistrationToken.TotalUsesAllowed, listedRegistrationToken.Uses)
+
+			d.Set("name", listedRegistrationToken.Name)
+			d.Set("token", listedRegistrationToken.Token)
+			d.Set("enabled", listedRegistrationToken.Permissions.Enabled)
+			d.Set("allowed_registration_client_types", listedRegistrationToken.Permissions.AllowedTypes)
+			d.Set("one_time_use", listedRegistrationToken.Permissions.OneTime)
+			// One time use tokens are limited by one_time_use rather than max_uses, which conflict
+			if !listedRegistrationToken.Permissions.OneTime {
+				d.Set("max_uses", listedRegistrationToken.TotalUsesAllowed)
+			}
+			d.Set("uses", listedRegistrationToken.Uses)
+			d.Set("uses_remaining", usesRemaining)
+			d.Set("consumed", usesRemaining == 0 || registrationTokenExpired(d.Get("expires_at").(string)))
 
-				d.Set("enabled", listedRegistrationToken.Permissions.Enabled)
-				d.Set("allowed_registration_client_types", []interface{}{listedRegistrationToken.Permissions.AllowedTypes})
+			// Tokens tracked by name before they were identified by token switch to the token when read
+			d.SetId(listedRegistrationToken.Token)
 
-				break
-			}
+			break
 		}
 	}
 
 	if !listed {
 		d.Set("token", "")
 		d.Set("enabled", false)
+		d.Set("uses_remaining", 0)
+		d.Set("consumed", true)
 	}
 
 	return diags
@@ -160,6 +224,12 @@ func resourceClientRegistrationTokenDelete(ctx context.Context, d *schema.Resour
 	var diags diag.Diagnostics
 	var err error
 
+	// Nothing to delete upstream if the token no longer exists
+	if d.Get("token").(string) == "" {
+		d.SetId("")
+		return diags
+	}
+
 	toznySDK, account, err := MakeToznySession(ctx, d, m)
 
 	if err != nil {
@@ -179,3 +249,89 @@ func resourceClientRegistrationTokenDelete(ctx context.Context, d *schema.Resour
 
 	return diags
 }
+
+// resourceClientRegistrationTokenImport imports a registration token by its name, which must be unique to the account.
+func resourceClientRegistrationTokenImport(ctx context.Context, d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+	toznySDK, account, err := MakeToznySession(ctx, d, m)
+
+	if err != nil {
+		return nil, err
+	}
+
+	listedRegistrationTokens, err := toznySDK.ListRegistrationTokens(ctx, account.Token)
+
+	if err != nil {
+		return nil, err
+	}
+
+	var matchingTokens []string
+
+	for _, listedRegistrationToken := range *listedRegistrationTokens {
+		if listedRegistrationToken.Name == d.Id() {
+			matchingTokens = append(matchingTokens, listedRegistrationToken.Token)
+		}
+	}
+
+	switch len(matchingTokens) {
+	case 0:
+		return nil, fmt.Errorf("unable to find registration token %q", d.Id())
+	case 1:
+		break
+	default:
+		return nil, fmt.Errorf("found %d registration tokens named %q, rename or delete the others to import it", len(matchingTokens), d.Id())
+	}
+
+	d.SetId(matchingTokens[0])
+	d.Set("token", matchingTokens[0])
+	d.Set("client_credentials_filepath", "")
+	d.Set("client_credentials_config", "")
+
+	return []*schema.ResourceData{d}, nil
+}
+
+// resourceClientRegistrationTokenCustomizeDiff plans the replacement of a registration token
+// that was found to be consumed during the last refresh so that a fresh token is issued.
+func resourceClientRegistrationTokenCustomizeDiff(ctx context.Context, d *schema.ResourceDiff, m interface{}) error {
+	if d.Id() == "" || !d.Get("consumed").(bool) {
+		return nil
+	}
+
+	if err := d.SetNew("consumed", false); err != nil {
+		return err
+	}
+
+	return d.ForceNew("consumed")
+}
+
+// registrationTokenUsesRemaining returns how many more clients can be registered with a token,
+// or -1 if the token places no limit on the number of registrations.
+func registrationTokenUsesRemaining(oneTimeUse bool, totalUsesAllowed int, uses int) int {
+	if oneTimeUse {
+		totalUsesAllowed = 1
+	}
+
+	if totalUsesAllowed <= 0 {
+		return -1
+	}
+
+	if uses >= totalUsesAllowed {
+		return 0
+	}
+
+	return totalUsesAllowed - uses
+}
+
+// registrationTokenExpired returns whether the specified RFC3339 expiry for a token has passed.
+// Tokens without an expiry never expire.
+func registrationTokenExpired(expiresAt string) bool {
+	if expiresAt == "" {
+		return false
+	}
+
+	expiry, err := time.Parse(time.RFC3339, expiresAt)
+	if err != nil {
+		return false
+	}
+
+	return !expiry.After(time.Now())
+}
@@ -13,6 +13,8 @@ func resourceIdentityProviderMapper() *schema.Resource {
 	return &schema.Resource{
 		CreateContext: resourceIdentityProviderMapperCreate,
 		ReadContext:   resourceIdentityProviderMapperRead,
+		UpdateContext: UpdateRealmRenamed(resourceIdentityProviderMapperRead),
+		CustomizeDiff: ForceNewUnlessRealmRenamed,
 		DeleteContext: resourceIdentityProviderMapperDelete,
 		Schema: map[string]*schema.Schema{
 			"client_credentials_filepath": {
@@ -41,8 +43,8 @@ func resourceIdentityProviderMapper() *schema.Resource {
 				Description: "The name of the realm to associate the provider with.",
 				Type:        schema.TypeString,
 				Required:    true,
-				ForceNew:    true,
 			},
+			"realm_id": RealmIDSchema(),
 			"alias": {
 				Description: "User defined unique ID for the provider.",
 				Type:        schema.TypeString,
@@ -137,6 +139,18 @@ func resourceIdentityProviderMapperRead(ctx context.Context, d *schema.ResourceD
 	if err != nil {
 		return diag.FromErr(err)
 	}
+
+	// Look the realm up by ID so the resource can still be read after the realm is renamed
+	realmExists, err := ReadRealmIntoState(ctx, toznySDK, d)
+
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	if !realmExists {
+		d.SetId("")
+		return diags
+	}
 	realmName := d.Get("realm_name").(string)
 	alias := d.Get("alias").(string)
 	mapperId := d.Get("mapper_id").(string)
@@ -17,6 +17,7 @@ func resourcePAMJiraPlugin() *schema.Resource {
 		ReadContext:   resourcePAMJiraPluginRead,
 		DeleteContext: resourcePAMJiraPluginDelete,
 		UpdateContext: resourcePAMJiraPluginUpdate,
+		CustomizeDiff: ForceNewUnlessRealmRenamed,
 		Schema: map[string]*schema.Schema{
 			"client_credentials_filepath": {
 				Description:   "The filepath to Tozny client credentials for the Terraform provider to use when provisioning this realm provider.",
@@ -39,8 +40,8 @@ func resourcePAMJiraPlugin() *schema.Resource {
 				Description: "Server defined unique identifier for a realm",
 				Type:        schema.TypeString,
 				Required:    true,
-				ForceNew:    true,
 			},
+			"realm_id": RealmIDSchema(),
 			"jira_host_url": {
 				Description: "The url of the jira instance with no protocol or trailing slash",
 				Type:        schema.TypeString,
@@ -100,6 +101,18 @@ func resourcePAMJiraPluginRead(ctx context.Context, d *schema.ResourceData, m in
 		return diag.FromErr(err)
 	}
 
+	// Look the realm up by ID so the resource can still be read after the realm is renamed
+	realmExists, err := ReadRealmIntoState(ctx, toznySDK, d)
+
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	if !realmExists {
+		d.SetId("")
+		return diags
+	}
+
 	id, err := strconv.ParseInt(d.Id(), 10, 64)
 	if err != nil {
 		return diag.FromErr(fmt.Errorf("unable to parse plugin id: %s %s", d.Id(), err))
@@ -146,6 +159,13 @@ func resourcePAMJiraPluginUpdate(ctx context.Context, d *schema.ResourceData, m
 		return diag.FromErr(err)
 	}
 
+	// Only renames of the realm are updated in place, pointing the resource at a different realm replaces it
+	err = CheckRealmRenamed(ctx, toznySDK, d)
+
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
 	pluginID, err := strconv.ParseInt(d.Id(), 10, 64)
 	if err != nil {
 		return diag.FromErr(fmt.Errorf("unable to parse plugin id: %s %s", d.Id(), err))
@@ -3,10 +3,13 @@ package tozny
 import (
 	"context"
 	"fmt"
+	"strings"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 	"github.com/tozny/e3db-clients-go/identityClient"
+	"github.com/tozny/e3db-go/v2"
 )
 
 // resourceRealm returns the schema and methods for provisioning a Tozny Realm.
@@ -16,6 +19,9 @@ func resourceRealm() *schema.Resource {
 		ReadContext:   resourceRealmRead,
 		DeleteContext: resourceRealmDelete,
 		UpdateContext: resourceRealmUpdate,
+		Importer: &schema.ResourceImporter{
+			StateContext: resourceRealmImport,
+		},
 		Schema: map[string]*schema.Schema{
 			"realm_id": {
 				Description: "Service defined unique identifier for the realm.",
@@ -38,8 +44,8 @@ func resourceRealm() *schema.Resource {
 			"active": {
 				Description: "Whether the realm is active for applications and identities to consume.",
 				Type:        schema.TypeBool,
+				Optional:    true,
 				Computed:    true,
-				ForceNew:    true,
 			},
 			"broker_identity_tozny_id": {
 				Description: "The Tozny Client ID associated with the Identity used to broker interactions between the realm and it's Identities. Will be empty if no realm broker Identity has been registered.",
@@ -65,11 +71,17 @@ func resourceRealm() *schema.Resource {
 				ConflictsWith: []string{"client_credentials_filepath"},
 			},
 			"realm_name": {
-				Description: "User defined identifier for the realm.",
+				Description: "User defined identifier for the realm, which can be changed to rename the realm in place.",
 				Type:        schema.TypeString,
 				Optional:    true,
 				Computed:    true,
 			},
+			"deletion_protection": {
+				Description: "Whether Terraform is prevented from destroying the realm. Must be set to false in a prior apply before the realm can be destroyed or replaced.",
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     true,
+			},
 			"default_registration_token": {
 				Description: "The default registration token to use for registering new Identities with this Realm",
 				Type:        schema.TypeString,
@@ -131,6 +143,116 @@ func resourceRealm() *schema.Resource {
 				Type:        schema.TypeString,
 				Optional:    true,
 			},
+			"sso_session_idle_timeout": {
+				Description:  "Seconds an SSO session can be idle before it expires.",
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Computed:     true,
+				ValidateFunc: validation.IntAtLeast(0),
+			},
+			"sso_session_max_lifespan": {
+				Description:  "Maximum seconds an SSO session can last before it expires.",
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Computed:     true,
+				ValidateFunc: validation.IntAtLeast(0),
+			},
+			"access_token_lifespan": {
+				Description:  "Seconds before an access token expires.",
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Computed:     true,
+				ValidateFunc: validation.IntAtLeast(0),
+			},
+			"revoke_refresh_token": {
+				Description: "Whether refresh tokens are revoked after being used more than `refresh_token_max_reuse` times.",
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Computed:    true,
+			},
+			"refresh_token_max_reuse": {
+				Description:  "Number of times a refresh token can be reused when `revoke_refresh_token` is enabled.",
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Computed:     true,
+				ValidateFunc: validation.IntAtLeast(0),
+			},
+			"offline_session_idle_timeout": {
+				Description:  "Seconds an offline session can be idle before it expires.",
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Computed:     true,
+				ValidateFunc: validation.IntAtLeast(0),
+			},
+			"offline_session_max_lifespan_enabled": {
+				Description: "Whether offline sessions have a maximum lifespan.",
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Computed:    true,
+			},
+			"offline_session_max_lifespan": {
+				Description:  "Maximum seconds an offline session can last when `offline_session_max_lifespan_enabled` is true.",
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Computed:     true,
+				ValidateFunc: validation.IntAtLeast(0),
+			},
+			"login_timeout": {
+				Description:  "Seconds an identity has to complete a login.",
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Computed:     true,
+				ValidateFunc: validation.IntAtLeast(0),
+			},
+			"login_action_timeout": {
+				Description:  "Seconds an identity has to complete login related actions, such as updating their password.",
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Computed:     true,
+				ValidateFunc: validation.IntAtLeast(0),
+			},
+			"registration_allowed": {
+				Description: "Whether identities can register themselves with the realm.",
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Computed:    true,
+			},
+			"registration_email_as_username": {
+				Description: "Whether identities registering with the realm use their email as their username.",
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Computed:    true,
+			},
+			"verify_email": {
+				Description: "Whether identities are required to verify their email address.",
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Computed:    true,
+			},
+			"login_with_email_allowed": {
+				Description: "Whether identities can log in with their email address as well as their username.",
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Computed:    true,
+			},
+			"duplicate_emails_allowed": {
+				Description: "Whether several identities can have the same email address.",
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Computed:    true,
+			},
+			"remember_me": {
+				Description: "Whether the login page offers identities to remember them between browser restarts.",
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Computed:    true,
+			},
+			"reset_password_allowed": {
+				Description: "Whether the login page offers identities to reset a forgotten password.",
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Computed:    true,
+			},
 		},
 	}
 }
@@ -145,6 +267,12 @@ func resourceRealmCreate(ctx context.Context, d *schema.ResourceData, m interfac
 		return diag.FromErr(err)
 	}
 
+	err = validateRealmRegistrationSettings(d)
+
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
 	realm, err := toznySDK.CreateRealm(ctx, identityClient.CreateRealmRequest{
 		RealmName:         d.Get("realm_name").(string),
 		SovereignName:     d.Get("sovereign_name").(string),
@@ -181,6 +309,41 @@ func resourceRealmCreate(ctx context.Context, d *schema.ResourceData, m interfac
 	if err != nil {
 		return diag.FromErr(err)
 	}
+
+	err = updateRealmSettings(ctx, toznySDK, d.Get("realm_name").(string), realmSessionSettingsFromSchema(d))
+
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	err = updateRealmSettings(ctx, toznySDK, d.Get("realm_name").(string), realmRegistrationSettingsFromSchema(d))
+
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	// Realms are created active so only realms configured as inactive need updating
+	if active, ok := d.GetOkExists("active"); ok && !active.(bool) {
+		realm.Active = false
+		err = updateRealm(ctx, toznySDK, d.Get("realm_name").(string), realmUpdate{
+			Active: &realm.Active,
+		})
+
+		if err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	settings, err := describeRealmSettings(ctx, toznySDK, d.Get("realm_name").(string))
+
+	// Without the realm settings endpoint the settings are left as configured
+	if err != nil && !IsServiceCallNotFound(err) {
+		return diag.FromErr(err)
+	}
+	if err == nil {
+		setRealmSessionSettings(d, settings)
+		setRealmRegistrationSettings(d, settings)
+	}
 	d.Set("realm_id", realm.ID)
 	d.Set("domain", realm.Domain)
 	d.Set("admin_url", realm.AdminURL)
@@ -221,17 +384,43 @@ func resourceRealmRead(ctx context.Context, d *schema.ResourceData, m interface{
 		return diag.FromErr(err)
 	}
 
-	realm, err := toznySDK.DescribeRealm(ctx, d.Get("realm_name").(string))
+	// Realms are looked up by ID as their name changes when they are renamed
+	realmName, exists, err := FindRealmNameByID(ctx, toznySDK, d.Id())
 
 	if err != nil {
 		return diag.FromErr(err)
 	}
 
-	privateRealmInfo, err := toznySDK.PrivateRealmInfo(ctx, d.Get("realm_name").(string))
+	if !exists {
+		d.SetId("")
+		return diags
+	}
+
+	err = readRealmIntoState(ctx, toznySDK, realmName, d)
+
 	if err != nil {
 		return diag.FromErr(err)
 	}
 
+	d.Set("realm_name", realmName)
+
+	return diags
+}
+
+// readRealmIntoState describes the named realm along with its public and private settings
+// and sets the values on the Terraform state for the realm, returning error (if any).
+func readRealmIntoState(ctx context.Context, toznySDK *e3db.ToznySDKV3, realmName string, d *schema.ResourceData) error {
+	realm, err := toznySDK.DescribeRealm(ctx, realmName)
+
+	if err != nil {
+		return err
+	}
+
+	privateRealmInfo, err := toznySDK.PrivateRealmInfo(ctx, realmName)
+	if err != nil {
+		return err
+	}
+
 	d.Set("realm_id", realm.ID)
 	d.Set("domain", realm.Domain)
 	d.Set("admin_url", realm.AdminURL)
@@ -247,20 +436,169 @@ func resourceRealmRead(ctx context.Context, d *schema.ResourceData, m interface{
 	d.Set("tozid_federation_enabled", privateRealmInfo.TozIDFederationEnabled)
 	d.Set("secrets_enabled", privateRealmInfo.SecretsEnabled)
 
-	realmInfo, err := toznySDK.RealmInfo(ctx, d.Get("realm_name").(string))
+	realmInfo, err := toznySDK.RealmInfo(ctx, realmName)
 	if err != nil {
-		return diag.FromErr(err)
+		return err
 	}
 	d.Set("forgot_password_custom_text", realmInfo.ForgotPasswordCustomText)
 	d.Set("forgot_password_custom_link", realmInfo.ForgotPasswordCustomLink)
 
-	return diags
+	settings, err := describeRealmSettings(ctx, toznySDK, realmName)
+	if IsServiceCallNotFound(err) {
+		// Identity services without the realm settings endpoint leave the settings unset
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	setRealmSessionSettings(d, settings)
+	setRealmRegistrationSettings(d, settings)
+
+	return nil
+}
+
+// setRealmSessionSettings sets the session and token lifetimes of a realm on the Terraform state for the realm.
+func setRealmSessionSettings(d *schema.ResourceData, settings *realmSettings) {
+	d.Set("sso_session_idle_timeout", intSetting(settings.SSOSessionIdleTimeout))
+	d.Set("sso_session_max_lifespan", intSetting(settings.SSOSessionMaxLifespan))
+	d.Set("access_token_lifespan", intSetting(settings.AccessTokenLifespan))
+	d.Set("revoke_refresh_token", boolSetting(settings.RevokeRefreshToken))
+	d.Set("refresh_token_max_reuse", intSetting(settings.RefreshTokenMaxReuse))
+	d.Set("offline_session_idle_timeout", intSetting(settings.OfflineSessionIdleTimeout))
+	d.Set("offline_session_max_lifespan_enabled", boolSetting(settings.OfflineSessionMaxLifespanEnabled))
+	d.Set("offline_session_max_lifespan", intSetting(settings.OfflineSessionMaxLifespan))
+	d.Set("login_timeout", intSetting(settings.LoginTimeout))
+	d.Set("login_action_timeout", intSetting(settings.LoginActionTimeout))
+}
+
+// realmSessionSettingsAttributes are the attributes of a realm that control session and token lifetimes.
+var realmSessionSettingsAttributes = []string{
+	"sso_session_idle_timeout",
+	"sso_session_max_lifespan",
+	"access_token_lifespan",
+	"revoke_refresh_token",
+	"refresh_token_max_reuse",
+	"offline_session_idle_timeout",
+	"offline_session_max_lifespan_enabled",
+	"offline_session_max_lifespan",
+	"login_timeout",
+	"login_action_timeout",
+}
+
+// realmSessionSettingsFromSchema builds the session and token lifetime settings for a realm. Only settings
+// configured when the realm is created, or changed since, are sent, including changes to 0 or false,
+// so the realm keeps its current value for the others.
+func realmSessionSettingsFromSchema(d *schema.ResourceData) realmSettings {
+	var settings realmSettings
+
+	lifetimes := map[string]**int{
+		"sso_session_idle_timeout":     &settings.SSOSessionIdleTimeout,
+		"sso_session_max_lifespan":     &settings.SSOSessionMaxLifespan,
+		"access_token_lifespan":        &settings.AccessTokenLifespan,
+		"refresh_token_max_reuse":      &settings.RefreshTokenMaxReuse,
+		"offline_session_idle_timeout": &settings.OfflineSessionIdleTimeout,
+		"offline_session_max_lifespan": &settings.OfflineSessionMaxLifespan,
+		"login_timeout":                &settings.LoginTimeout,
+		"login_action_timeout":         &settings.LoginActionTimeout,
+	}
+	for attribute, setting := range lifetimes {
+		if value, ok := realmSettingFromSchema(d, attribute); ok {
+			lifetime := value.(int)
+			*setting = &lifetime
+		}
+	}
+
+	toggles := map[string]**bool{
+		"revoke_refresh_token":                 &settings.RevokeRefreshToken,
+		"offline_session_max_lifespan_enabled": &settings.OfflineSessionMaxLifespanEnabled,
+	}
+	for attribute, setting := range toggles {
+		if value, ok := realmSettingFromSchema(d, attribute); ok {
+			enabled := value.(bool)
+			*setting = &enabled
+		}
+	}
+
+	return settings
+}
+
+// realmSettingFromSchema returns the value of a realm setting attribute and whether to send it, which is when it
+// is configured for a realm being created, or has changed for an existing realm.
+func realmSettingFromSchema(d *schema.ResourceData, attribute string) (interface{}, bool) {
+	if d.IsNewResource() {
+		return d.GetOkExists(attribute)
+	}
+	return d.Get(attribute), d.HasChange(attribute)
+}
+
+// setRealmRegistrationSettings sets the self-registration and login options of a realm on the Terraform state for the realm.
+func setRealmRegistrationSettings(d *schema.ResourceData, settings *realmSettings) {
+	d.Set("registration_allowed", boolSetting(settings.RegistrationAllowed))
+	d.Set("registration_email_as_username", boolSetting(settings.RegistrationEmailAsUsername))
+	d.Set("verify_email", boolSetting(settings.VerifyEmail))
+	d.Set("login_with_email_allowed", boolSetting(settings.LoginWithEmailAllowed))
+	d.Set("duplicate_emails_allowed", boolSetting(settings.DuplicateEmailsAllowed))
+	d.Set("remember_me", boolSetting(settings.RememberMe))
+	d.Set("reset_password_allowed", boolSetting(settings.ResetPasswordAllowed))
+}
+
+// realmRegistrationSettingsAttributes are the attributes of a realm that control self-registration and login options.
+var realmRegistrationSettingsAttributes = []string{
+	"registration_allowed",
+	"registration_email_as_username",
+	"verify_email",
+	"login_with_email_allowed",
+	"duplicate_emails_allowed",
+	"remember_me",
+	"reset_password_allowed",
+}
+
+// realmRegistrationSettingsFromSchema builds the self-registration and login options for a realm,
+// leaving any options that have not been set to the realm's current value.
+func realmRegistrationSettingsFromSchema(d *schema.ResourceData) realmSettings {
+	var settings realmSettings
+
+	options := map[string]**bool{
+		"registration_allowed":           &settings.RegistrationAllowed,
+		"registration_email_as_username": &settings.RegistrationEmailAsUsername,
+		"verify_email":                   &settings.VerifyEmail,
+		"login_with_email_allowed":       &settings.LoginWithEmailAllowed,
+		"duplicate_emails_allowed":       &settings.DuplicateEmailsAllowed,
+		"remember_me":                    &settings.RememberMe,
+		"reset_password_allowed":         &settings.ResetPasswordAllowed,
+	}
+	for attribute, setting := range options {
+		if value, ok := d.GetOkExists(attribute); ok {
+			enabled := value.(bool)
+			*setting = &enabled
+		}
+	}
+
+	return settings
+}
+
+// validateRealmRegistrationSettings checks that the self-registration and login options
+// of a realm can be used together, returning error (if any).
+func validateRealmRegistrationSettings(d *schema.ResourceData) error {
+	if !d.Get("duplicate_emails_allowed").(bool) {
+		return nil
+	}
+	for _, attribute := range []string{"login_with_email_allowed", "registration_email_as_username"} {
+		if d.Get(attribute).(bool) {
+			return fmt.Errorf("duplicate_emails_allowed can not be enabled while %s is enabled", attribute)
+		}
+	}
+	return nil
 }
 
 func resourceRealmDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	var diags diag.Diagnostics
 	var err error
 
+	if protected := CheckDeletionProtection(d, "Realm", d.Get("realm_name").(string)); protected != nil {
+		return protected
+	}
+
 	toznySDK, err := MakeToznySDK(d, m)
 
 	if err != nil {
@@ -285,6 +623,28 @@ func resourceRealmUpdate(ctx context.Context, d *schema.ResourceData, m interfac
 		return diag.FromErr(err)
 	}
 
+	// Rename the realm first so the remaining updates apply to the realm under its new name
+	if d.HasChange("realm_name") {
+		oldName, newName := d.GetChange("realm_name")
+		realmName := newName.(string)
+		err = updateRealm(ctx, toznySDK, oldName.(string), realmUpdate{
+			Name: &realmName,
+		})
+		if err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	if d.HasChange("active") {
+		active := d.Get("active").(bool)
+		err = updateRealm(ctx, toznySDK, d.Get("realm_name").(string), realmUpdate{
+			Active: &active,
+		})
+		if err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
 	if d.HasChanges("mpc_enabled", "secrets_enabled", "tozid_federation_enabled", "forgot_password_custom_link", "forgot_password_custom_text") {
 
 		federation_setting := d.Get("tozid_federation_enabled").(bool)
@@ -317,5 +677,64 @@ func resourceRealmUpdate(ctx context.Context, d *schema.ResourceData, m interfac
 
 	}
 
+	if d.HasChanges(realmSessionSettingsAttributes...) {
+		err = updateRealmSettings(ctx, toznySDK, d.Get("realm_name").(string), realmSessionSettingsFromSchema(d))
+		if err != nil {
+			return diag.FromErr(err)
+		}
+
+		settings, err := describeRealmSettings(ctx, toznySDK, d.Get("realm_name").(string))
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		setRealmSessionSettings(d, settings)
+	}
+
+	if d.HasChanges(realmRegistrationSettingsAttributes...) {
+		err = validateRealmRegistrationSettings(d)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+
+		err = updateRealmSettings(ctx, toznySDK, d.Get("realm_name").(string), realmRegistrationSettingsFromSchema(d))
+		if err != nil {
+			return diag.FromErr(err)
+		}
+
+		settings, err := describeRealmSettings(ctx, toznySDK, d.Get("realm_name").(string))
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		setRealmRegistrationSettings(d, settings)
+	}
+
 	return diags
 }
+
+// resourceRealmImport imports the realm named by the import ID.
+func resourceRealmImport(ctx context.Context, d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+	realmName := d.Id()
+
+	toznySDK, err := MakeToznySDK(d, m)
+	if err != nil {
+		return nil, err
+	}
+
+	listedRealms, err := toznySDK.ListRealms(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, realm := range listedRealms.Realms {
+		if strings.EqualFold(realm.Name, realmName) {
+			d.SetId(fmt.Sprintf("%d", realm.ID))
+			d.Set("realm_name", realm.Name)
+			d.Set("deletion_protection", true)
+			d.Set("client_credentials_filepath", "")
+			d.Set("client_credentials_config", "")
+			return []*schema.ResourceData{d}, nil
+		}
+	}
+
+	return nil, fmt.Errorf("unable to find realm %q", realmName)
+}
@@ -16,6 +16,10 @@ func resourceRealmApplication() *schema.Resource {
 		ReadContext:   resourceRealmApplicationRead,
 		DeleteContext: resourceRealmApplicationDelete,
 		UpdateContext: resourceRealmApplicationUpdate,
+		CustomizeDiff: ForceNewUnlessRealmRenamed,
+		Importer: &schema.ResourceImporter{
+			StateContext: resourceRealmApplicationImport,
+		},
 		Schema: map[string]*schema.Schema{
 			"client_credentials_filepath": {
 				Description:   "The filepath to Tozny client credentials for the provider to use when provisioning this application.",
@@ -38,7 +42,13 @@ func resourceRealmApplication() *schema.Resource {
 				Description: "The name of the Realm to provision the Application for.",
 				Type:        schema.TypeString,
 				Required:    true,
-				ForceNew:    true,
+			},
+			"realm_id": RealmIDSchema(),
+			"deletion_protection": {
+				Description: "Whether Terraform is prevented from destroying the application. When enabled, it must be set to false in a prior apply before the application can be destroyed or replaced.",
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
 			},
 			"application_id": {
 				Description: "Server defined unique identifier for the Application.",
@@ -290,6 +300,13 @@ func resourceRealmApplicationUpdate(ctx context.Context, d *schema.ResourceData,
 		return diag.FromErr(err)
 	}
 
+	// Only renames of the realm are updated in place, pointing the resource at a different realm replaces it
+	err = CheckRealmRenamed(ctx, toznySDK, d)
+
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
 	updateApplicationSetting := identityClient.UpdateRealmApplicationRequest{
 		RealmName: d.Get("realm_name").(string),
 		ApplicationSettings: identityClient.UpdateApplicationSettings{
@@ -354,6 +371,18 @@ func resourceRealmApplicationRead(ctx context.Context, d *schema.ResourceData, m
 		return diag.FromErr(err)
 	}
 
+	// Look the realm up by ID so the resource can still be read after the realm is renamed
+	realmExists, err := ReadRealmIntoState(ctx, toznySDK, d)
+
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	if !realmExists {
+		d.SetId("")
+		return diags
+	}
+
 	application, err := toznySDK.DescribeRealmApplication(ctx, identityClient.DeleteRealmApplicationRequest{
 		RealmName:     d.Get("realm_name").(string),
 		ApplicationID: d.Get("application_id").(string),
@@ -371,7 +400,10 @@ func resourceRealmApplicationRead(ctx context.Context, d *schema.ResourceData, m
 	maybeTerraformSAMLSettings := d.Get("saml_settings").([]interface{})
 	// only set oidc settings if no oidc_settings
 
-	if len(maybeTerraformSAMLSettings) == 0 {
+	// Imported applications have neither settings in state, so use the settings of the application's protocol
+	isImportedSAMLApplication := len(maybeTerraformSAMLSettings) == 0 && len(d.Get("oidc_settings").([]interface{})) == 0 && strings.EqualFold(application.Protocol, "saml")
+
+	if len(maybeTerraformSAMLSettings) == 0 && !isImportedSAMLApplication {
 		d.Set("oidc_settings", []interface{}{
 			map[string]interface{}{
 				"allowed_origins":              application.AllowedOrigins,
@@ -413,6 +445,10 @@ func resourceRealmApplicationRead(ctx context.Context, d *schema.ResourceData, m
 func resourceRealmApplicationDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	var diags diag.Diagnostics
 
+	if protected := CheckDeletionProtection(d, "Application", d.Get("client_id").(string)); protected != nil {
+		return protected
+	}
+
 	toznySDK, err := MakeToznySDK(d, m)
 
 	if err != nil {
@@ -433,3 +469,20 @@ func resourceRealmApplicationDelete(ctx context.Context, d *schema.ResourceData,
 
 	return diags
 }
+
+// resourceRealmApplicationImport imports a realm application using an import ID of the form `realm_name/application_id`.
+func resourceRealmApplicationImport(ctx context.Context, d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+	realmName, applicationID, err := ParseRealmScopedImportID(d.Id())
+	if err != nil {
+		return nil, err
+	}
+
+	d.SetId(applicationID)
+	d.Set("realm_name", realmName)
+	d.Set("application_id", applicationID)
+	d.Set("deletion_protection", false)
+	d.Set("client_credentials_filepath", "")
+	d.Set("client_credentials_config", "")
+
+	return []*schema.ResourceData{d}, nil
+}
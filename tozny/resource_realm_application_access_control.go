@@ -15,6 +15,7 @@ func resourceRealmApplicationAccessControl() *schema.Resource {
 		UpdateContext: resourceRealmApplicationAccessControlUpdate,
 		DeleteContext: resourceRealmApplicationAccessControlDelete,
 		ReadContext:   resourceRealmApplicationAccessControlRead,
+		CustomizeDiff: ForceNewUnlessRealmRenamed,
 		Schema: map[string]*schema.Schema{
 			"client_credentials_filepath": {
 				Description:   "The filepath to Tozny client credentials for the Terraform provider to use when provisioning role mappings for this realm group.",
@@ -37,8 +38,8 @@ func resourceRealmApplicationAccessControl() *schema.Resource {
 				Description: "The name of the Realm associated with the application.",
 				Type:        schema.TypeString,
 				Required:    true,
-				ForceNew:    true,
 			},
+			"realm_id": RealmIDSchema(),
 			"application_id": {
 				Description: "Server defined unique identifier for the application.",
 				Type:        schema.TypeString,
@@ -123,6 +124,13 @@ func resourceRealmApplicationAccessControlCreate(ctx context.Context, d *schema.
 func resourceRealmApplicationAccessControlUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	var diags diag.Diagnostics
 	toznySDK, err := MakeToznySDK(d, m)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	// Only renames of the realm are updated in place, pointing the resource at a different realm replaces it
+	err = CheckRealmRenamed(ctx, toznySDK, d)
+
 	if err != nil {
 		return diag.FromErr(err)
 	}
@@ -256,6 +264,24 @@ func resourceRealmApplicationAccessControlDelete(ctx context.Context, d *schema.
 }
 func resourceRealmApplicationAccessControlRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	var diags diag.Diagnostics
+
+	toznySDK, err := MakeToznySDK(d, m)
+
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	// Look the realm up by ID so the resource can still be read after the realm is renamed
+	realmExists, err := ReadRealmIntoState(ctx, toznySDK, d)
+
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	if !realmExists {
+		d.SetId("")
+		return diags
+	}
 	applicationID := d.Get("application_id").(string)
 	realmName := d.Get("realm_name").(string)
 	groups := d.Get("group").([]interface{})
@@ -16,6 +16,8 @@ func resourceRealmApplicationClientSecret() *schema.Resource {
 	return &schema.Resource{
 		CreateContext: resourceRealmApplicationClientSecretRead,
 		ReadContext:   resourceRealmApplicationClientSecretRead,
+		UpdateContext: UpdateRealmRenamed(resourceRealmApplicationClientSecretRead),
+		CustomizeDiff: ForceNewUnlessRealmRenamed,
 		DeleteContext: resourceRealmApplicationClientSecretDelete,
 		Schema: map[string]*schema.Schema{
 			"client_credentials_filepath": {
@@ -39,8 +41,8 @@ func resourceRealmApplicationClientSecret() *schema.Resource {
 				Description: "The name of the realm the application is associated with.",
 				Type:        schema.TypeString,
 				Required:    true,
-				ForceNew:    true,
 			},
+			"realm_id": RealmIDSchema(),
 			"application_id": {
 				Description: "The application ID to retrieve the client secret for.",
 				Type:        schema.TypeString,
@@ -79,6 +81,18 @@ func resourceRealmApplicationClientSecretRead(ctx context.Context, d *schema.Res
 		return diag.FromErr(err)
 	}
 
+	// Look the realm up by ID so the resource can still be read after the realm is renamed
+	realmExists, err := ReadRealmIntoState(ctx, toznySDK, d)
+
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	if !realmExists {
+		d.SetId("")
+		return diags
+	}
+
 	fetchApplicationClientSecretParams := identityClient.FetchApplicationSecretRequest{
 		RealmName:     strings.ToLower(d.Get("realm_name").(string)),
 		ApplicationID: d.Get("application_id").(string),
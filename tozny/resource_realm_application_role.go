@@ -2,6 +2,7 @@ package tozny
 
 import (
 	"context"
+	"fmt"
 	"strings"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
@@ -14,7 +15,12 @@ func resourceRealmApplicationRole() *schema.Resource {
 	return &schema.Resource{
 		CreateContext: resourceRealmApplicationRoleCreate,
 		ReadContext:   resourceRealmApplicationRoleRead,
+		UpdateContext: UpdateRealmRenamed(resourceRealmApplicationRoleRead),
+		CustomizeDiff: ForceNewUnlessRealmRenamed,
 		DeleteContext: resourceRealmApplicationRoleDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: resourceRealmApplicationRoleImport,
+		},
 		Schema: map[string]*schema.Schema{
 			"client_credentials_filepath": {
 				Description:   "The filepath to Tozny client credentials for the Terraform provider to use when provisioning this realm provider.",
@@ -37,8 +43,8 @@ func resourceRealmApplicationRole() *schema.Resource {
 				Description: "The name of the Realm to provision the Application Role for.",
 				Type:        schema.TypeString,
 				Required:    true,
-				ForceNew:    true,
 			},
+			"realm_id": RealmIDSchema(),
 			"application_id": {
 				Description: "Server defined unique identifier for the Application.",
 				Type:        schema.TypeString,
@@ -108,6 +114,18 @@ func resourceRealmApplicationRoleRead(ctx context.Context, d *schema.ResourceDat
 		return diag.FromErr(err)
 	}
 
+	// Look the realm up by ID so the resource can still be read after the realm is renamed
+	realmExists, err := ReadRealmIntoState(ctx, toznySDK, d)
+
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	if !realmExists {
+		d.SetId("")
+		return diags
+	}
+
 	applicationRole, err := toznySDK.DescribeRealmApplicationRole(ctx, identityClient.DescribeRealmApplicationRoleRequest{
 		RealmName:           strings.ToLower(d.Get("realm_name").(string)),
 		ApplicationID:       d.Get("application_id").(string),
@@ -118,6 +136,7 @@ func resourceRealmApplicationRoleRead(ctx context.Context, d *schema.ResourceDat
 		return diag.FromErr(err)
 	}
 
+	d.Set("application_role_id", applicationRole.ID)
 	d.Set("name", applicationRole.Name)
 	d.Set("description", applicationRole.Description)
 
@@ -147,3 +166,39 @@ func resourceRealmApplicationRoleDelete(ctx context.Context, d *schema.ResourceD
 
 	return diags
 }
+
+// resourceRealmApplicationRoleImport imports an application role using an import ID of the form `realm_name/application_id/role_name`.
+func resourceRealmApplicationRoleImport(ctx context.Context, d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+	realmName, applicationScopedID, err := ParseRealmScopedImportID(d.Id())
+	if err != nil {
+		return nil, err
+	}
+
+	parts := strings.SplitN(applicationScopedID, "/", 2)
+	if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+		return nil, fmt.Errorf("invalid import ID %q, expected realm_name/application_id/role_name", d.Id())
+	}
+
+	toznySDK, err := MakeToznySDK(d, m)
+	if err != nil {
+		return nil, err
+	}
+
+	applicationRole, err := toznySDK.DescribeRealmApplicationRole(ctx, identityClient.DescribeRealmApplicationRoleRequest{
+		RealmName:           strings.ToLower(realmName),
+		ApplicationID:       parts[0],
+		ApplicationRoleName: parts[1],
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	d.SetId(applicationRole.ID)
+	d.Set("realm_name", realmName)
+	d.Set("application_id", parts[0])
+	d.Set("name", applicationRole.Name)
+	d.Set("client_credentials_filepath", "")
+	d.Set("client_credentials_config", "")
+
+	return []*schema.ResourceData{d}, nil
+}
@@ -0,0 +1,260 @@
+package tozny
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+// resourceRealmAuthenticationExecution returns the schema and methods for provisioning a step of a Tozny Realm authentication flow
+func resourceRealmAuthenticationExecution() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceRealmAuthenticationExecutionCreate,
+		ReadContext:   resourceRealmAuthenticationExecutionRead,
+		UpdateContext: resourceRealmAuthenticationExecutionUpdate,
+		DeleteContext: resourceRealmAuthenticationExecutionDelete,
+		CustomizeDiff: ForceNewUnlessRealmRenamed,
+		Importer: &schema.ResourceImporter{
+			StateContext: resourceRealmAuthenticationExecutionImport,
+		},
+		Schema: map[string]*schema.Schema{
+			"client_credentials_filepath": {
+				Description:   "The filepath to Tozny client credentials for the provider to use when provisioning this execution.",
+				Type:          schema.TypeString,
+				Optional:      true,
+				Default:       "",
+				ForceNew:      true,
+				ConflictsWith: []string{"client_credentials_config"},
+			},
+			"client_credentials_config": {
+				Description:   "The Tozny account client configuration as a JSON string",
+				Type:          schema.TypeString,
+				Optional:      true,
+				Default:       "",
+				ForceNew:      true,
+				Sensitive:     true,
+				ConflictsWith: []string{"client_credentials_filepath"},
+			},
+			"realm_name": {
+				Description: "Name of the realm to provision the execution for.",
+				Type:        schema.TypeString,
+				Required:    true,
+			},
+			"realm_id": RealmIDSchema(),
+			"parent_flow_alias": {
+				Description: "Alias of the flow (or sub flow) to add the execution to.",
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+			},
+			"authenticator": {
+				Description:  "Provider ID of the authenticator to run, e.g. `auth-cookie`, `auth-username-password-form`, `auth-otp-form`, `webauthn-authenticator` or `conditional-user-configured`.",
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      "",
+				ForceNew:     true,
+				ExactlyOneOf: []string{"authenticator", "sub_flow_alias"},
+			},
+			"sub_flow_alias": {
+				Description: "Alias of a sub flow to create and run rather than an authenticator, used to group steps such as a `CONDITIONAL` second factor.",
+				Type:        schema.TypeString,
+				Optional:    true,
+				Default:     "",
+				ForceNew:    true,
+			},
+			"sub_flow_type": {
+				Description:  "Type of the sub flow, `basic-flow` or `form-flow`.",
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      "basic-flow",
+				ForceNew:     true,
+				ValidateFunc: validation.StringInSlice([]string{"basic-flow", "form-flow"}, false),
+			},
+			"requirement": {
+				Description:  "Whether the execution must succeed for the flow to succeed. Valid values are `REQUIRED`, `ALTERNATIVE`, `CONDITIONAL` and `DISABLED`.",
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      "DISABLED",
+				ValidateFunc: validation.StringInSlice([]string{"REQUIRED", "ALTERNATIVE", "CONDITIONAL", "DISABLED"}, false),
+			},
+			"priority": {
+				Description:  "Order in which the execution runs relative to the other executions of the parent flow, lower values first. Defaults to after the parent flow's existing executions.",
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Computed:     true,
+				ValidateFunc: validation.IntAtLeast(0),
+			},
+			"config": {
+				Description: "Configuration of the authenticator, e.g. `condUserRole` for `conditional-user-role`.",
+				Type:        schema.TypeMap,
+				Optional:    true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"execution_id": {
+				Description: "Service defined unique identifier of the execution.",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+		},
+	}
+}
+
+// realmAuthenticationExecutionFromSchema builds the execution described by the resource's schema.
+func realmAuthenticationExecutionFromSchema(d *schema.ResourceData) realmAuthenticationExecution {
+	execution := realmAuthenticationExecution{
+		ID:            d.Id(),
+		Authenticator: d.Get("authenticator").(string),
+		SubFlowAlias:  d.Get("sub_flow_alias").(string),
+		Requirement:   d.Get("requirement").(string),
+		Priority:      -1,
+		Config:        map[string]string{},
+	}
+	if execution.SubFlowAlias != "" {
+		execution.SubFlowType = d.Get("sub_flow_type").(string)
+	}
+	if priority, ok := d.GetOkExists("priority"); ok {
+		execution.Priority = priority.(int)
+	}
+	for key, value := range d.Get("config").(map[string]interface{}) {
+		execution.Config[key] = value.(string)
+	}
+	return execution
+}
+
+func resourceRealmAuthenticationExecutionCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	toznySDK, err := MakeToznySDK(d, m)
+
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	// A negative priority asks the service to add the execution after the flow's existing executions
+	var execution realmAuthenticationExecution
+	err = makeIdentityServiceCall(ctx, toznySDK, http.MethodPost, realmAuthenticationExecutionsPath(d.Get("realm_name").(string), d.Get("parent_flow_alias").(string)), realmAuthenticationExecutionFromSchema(d), &execution)
+
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(execution.ID)
+
+	return resourceRealmAuthenticationExecutionRead(ctx, d, m)
+}
+
+func resourceRealmAuthenticationExecutionRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	toznySDK, err := MakeToznySDK(d, m)
+
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	// Look the realm up by ID so the resource can still be read after the realm is renamed
+	realmExists, err := ReadRealmIntoState(ctx, toznySDK, d)
+
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	if !realmExists {
+		d.SetId("")
+		return diags
+	}
+
+	var execution realmAuthenticationExecution
+	err = makeIdentityServiceCall(ctx, toznySDK, http.MethodGet, realmAuthenticationExecutionPath(d.Get("realm_name").(string), d.Get("parent_flow_alias").(string), d.Id()), nil, &execution)
+
+	if err != nil {
+		if IsServiceCallNotFound(err) {
+			d.SetId("")
+			return diags
+		}
+		return diag.FromErr(err)
+	}
+
+	d.Set("execution_id", execution.ID)
+	d.Set("authenticator", execution.Authenticator)
+	d.Set("sub_flow_alias", execution.SubFlowAlias)
+	if execution.SubFlowType != "" {
+		d.Set("sub_flow_type", execution.SubFlowType)
+	}
+	d.Set("requirement", execution.Requirement)
+	d.Set("priority", execution.Priority)
+	d.Set("config", execution.Config)
+
+	return diags
+}
+
+func resourceRealmAuthenticationExecutionUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	toznySDK, err := MakeToznySDK(d, m)
+
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	// Only renames of the realm are updated in place, pointing the resource at a different realm replaces it
+	err = CheckRealmRenamed(ctx, toznySDK, d)
+
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	if d.HasChanges("requirement", "priority", "config") {
+		err = makeIdentityServiceCall(ctx, toznySDK, http.MethodPut, realmAuthenticationExecutionPath(d.Get("realm_name").(string), d.Get("parent_flow_alias").(string), d.Id()), realmAuthenticationExecutionFromSchema(d), nil)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	return resourceRealmAuthenticationExecutionRead(ctx, d, m)
+}
+
+func resourceRealmAuthenticationExecutionDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	toznySDK, err := MakeToznySDK(d, m)
+
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	// Deleting a sub flow execution also deletes the sub flow and its executions
+	err = makeIdentityServiceCall(ctx, toznySDK, http.MethodDelete, realmAuthenticationExecutionPath(d.Get("realm_name").(string), d.Get("parent_flow_alias").(string), d.Id()), nil, nil)
+
+	if err != nil && !IsServiceCallNotFound(err) {
+		return diag.FromErr(err)
+	}
+
+	d.SetId("")
+
+	return diags
+}
+
+// resourceRealmAuthenticationExecutionImport imports an authentication execution using an import ID of the form `realm_name/parent_flow_alias/execution_id`.
+func resourceRealmAuthenticationExecutionImport(ctx context.Context, d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+	realmName, flowScopedID, err := ParseRealmScopedImportID(d.Id())
+	if err != nil {
+		return nil, err
+	}
+
+	parts := strings.SplitN(flowScopedID, "/", 2)
+	if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+		return nil, fmt.Errorf("invalid import ID %q, expected realm_name/parent_flow_alias/execution_id", d.Id())
+	}
+
+	d.SetId(parts[1])
+	d.Set("realm_name", realmName)
+	d.Set("parent_flow_alias", parts[0])
+	d.Set("client_credentials_filepath", "")
+	d.Set("client_credentials_config", "")
+
+	return []*schema.ResourceData{d}, nil
+}
@@ -0,0 +1,435 @@
+package tozny
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/tozny/e3db-go/v2"
+)
+
+// resourceRealmAuthenticationFlow returns the schema and methods for provisioning a Tozny Realm authentication flow
+func resourceRealmAuthenticationFlow() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceRealmAuthenticationFlowCreate,
+		ReadContext:   resourceRealmAuthenticationFlowRead,
+		UpdateContext: resourceRealmAuthenticationFlowUpdate,
+		DeleteContext: resourceRealmAuthenticationFlowDelete,
+		CustomizeDiff: ForceNewUnlessRealmRenamed,
+		Importer: &schema.ResourceImporter{
+			StateContext: resourceRealmAuthenticationFlowImport,
+		},
+		Schema: map[string]*schema.Schema{
+			"client_credentials_filepath": {
+				Description:   "The filepath to Tozny client credentials for the provider to use when provisioning this flow.",
+				Type:          schema.TypeString,
+				Optional:      true,
+				Default:       "",
+				ForceNew:      true,
+				ConflictsWith: []string{"client_credentials_config"},
+			},
+			"client_credentials_config": {
+				Description:   "The Tozny account client configuration as a JSON string",
+				Type:          schema.TypeString,
+				Optional:      true,
+				Default:       "",
+				ForceNew:      true,
+				Sensitive:     true,
+				ConflictsWith: []string{"client_credentials_filepath"},
+			},
+			"realm_name": {
+				Description: "Name of the realm to provision the flow for.",
+				Type:        schema.TypeString,
+				Required:    true,
+			},
+			"realm_id": RealmIDSchema(),
+			"alias": {
+				Description: "User defined unique name of the flow.",
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+			},
+			"description": {
+				Description: "User defined description of the flow.",
+				Type:        schema.TypeString,
+				Optional:    true,
+				Default:     "",
+			},
+			"provider_id": {
+				Description:  "Type of the flow, `basic-flow` for flows that authenticate identities or `client-flow` for flows that authenticate applications.",
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      "basic-flow",
+				ForceNew:     true,
+				ValidateFunc: validation.StringInSlice([]string{"basic-flow", "client-flow"}, false),
+			},
+			"copy_from": {
+				Description:   "Alias of an existing flow (e.g. the built-in `browser` flow) to copy, including its executions, rather than creating an empty flow.",
+				Type:          schema.TypeString,
+				Optional:      true,
+				Default:       "",
+				ForceNew:      true,
+				ConflictsWith: []string{"provider_id"},
+			},
+			"realm_binding": {
+				Description:  "Realm flow to replace with this flow. Valid values are `browser`, `direct_grant`, `registration`, `reset_credentials`, `client_authentication` and `docker`. Empty for flows that are only bound to applications or used as sub flows.",
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      "",
+				ValidateFunc: validation.StringInSlice([]string{"", "browser", "direct_grant", "registration", "reset_credentials", "client_authentication", "docker"}, false),
+			},
+			"application_override": {
+				Description: "Applications that use this flow instead of the realm's flow.",
+				Type:        schema.TypeSet,
+				Optional:    true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"application_id": {
+							Description: "Service defined unique identifier of the application.",
+							Type:        schema.TypeString,
+							Required:    true,
+						},
+						"binding": {
+							Description:  "Application flow to replace with this flow. Valid values are `browser` and `direct_grant`.",
+							Type:         schema.TypeString,
+							Required:     true,
+							ValidateFunc: validation.StringInSlice([]string{"browser", "direct_grant"}, false),
+						},
+					},
+				},
+			},
+			"flow_id": {
+				Description: "Service defined unique identifier of the flow.",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+			"built_in": {
+				Description: "Whether the flow is provided by the service.",
+				Type:        schema.TypeBool,
+				Computed:    true,
+			},
+		},
+	}
+}
+
+func resourceRealmAuthenticationFlowCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	toznySDK, err := MakeToznySDK(d, m)
+
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	realmName := d.Get("realm_name").(string)
+	alias := d.Get("alias").(string)
+
+	var flow realmAuthenticationFlow
+	if copyFrom := d.Get("copy_from").(string); copyFrom != "" {
+		err = makeIdentityServiceCall(ctx, toznySDK, http.MethodPost, realmAuthenticationFlowPath(realmName, copyFrom)+"/copy", realmAuthenticationFlowCopy{
+			NewName: alias,
+		}, &flow)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		// Copies take the description of the original flow
+		flow.Description = d.Get("description").(string)
+		err = makeIdentityServiceCall(ctx, toznySDK, http.MethodPut, realmAuthenticationFlowPath(realmName, flow.ID), flow, nil)
+	} else {
+		err = makeIdentityServiceCall(ctx, toznySDK, http.MethodPost, realmAuthenticationFlowsPath(realmName), realmAuthenticationFlow{
+			Alias:       alias,
+			Description: d.Get("description").(string),
+			ProviderID:  d.Get("provider_id").(string),
+			TopLevel:    true,
+		}, &flow)
+	}
+
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(flow.ID)
+
+	if binding := d.Get("realm_binding").(string); binding != "" {
+		err = bindRealmAuthenticationFlow(ctx, toznySDK, realmName, binding, alias)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	err = updateRealmAuthenticationFlowOverrides(ctx, toznySDK, realmName, flow.ID, nil, d.Get("application_override").(*schema.Set).List())
+
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	return resourceRealmAuthenticationFlowRead(ctx, d, m)
+}
+
+func resourceRealmAuthenticationFlowRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	toznySDK, err := MakeToznySDK(d, m)
+
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	// Look the realm up by ID so the resource can still be read after the realm is renamed
+	realmExists, err := ReadRealmIntoState(ctx, toznySDK, d)
+
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	if !realmExists {
+		d.SetId("")
+		return diags
+	}
+
+	realmName := d.Get("realm_name").(string)
+
+	var flow realmAuthenticationFlow
+	err = makeIdentityServiceCall(ctx, toznySDK, http.MethodGet, realmAuthenticationFlowPath(realmName, d.Id()), nil, &flow)
+
+	if err != nil {
+		if IsServiceCallNotFound(err) {
+			d.SetId("")
+			return diags
+		}
+		return diag.FromErr(err)
+	}
+
+	d.Set("flow_id", flow.ID)
+	d.Set("alias", flow.Alias)
+	d.Set("description", flow.Description)
+	d.Set("provider_id", flow.ProviderID)
+	d.Set("built_in", flow.BuiltIn)
+
+	// Only report the realm binding while the realm still uses this flow for it
+	if binding := d.Get("realm_binding").(string); binding != "" {
+		settings, err := describeRealmSettings(ctx, toznySDK, realmName)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		if stringSetting(*realmFlowBindings[binding].setting(settings)) != flow.Alias {
+			d.Set("realm_binding", "")
+		}
+	}
+
+	overrides := []interface{}{}
+	for _, override := range d.Get("application_override").(*schema.Set).List() {
+		override := override.(map[string]interface{})
+		applicationOverrides, err := listRealmApplicationFlowOverrides(ctx, toznySDK, realmName, override["application_id"].(string))
+		if err != nil {
+			if IsServiceCallNotFound(err) {
+				continue
+			}
+			return diag.FromErr(err)
+		}
+		if applicationOverrides[override["binding"].(string)] == flow.ID {
+			overrides = append(overrides, override)
+		}
+	}
+
+	if err := d.Set("application_override", overrides); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return diags
+}
+
+func resourceRealmAuthenticationFlowUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	toznySDK, err := MakeToznySDK(d, m)
+
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	// Only renames of the realm are updated in place, pointing the resource at a different realm replaces it
+	err = CheckRealmRenamed(ctx, toznySDK, d)
+
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	realmName := d.Get("realm_name").(string)
+	alias := d.Get("alias").(string)
+
+	if d.HasChange("description") {
+		err = makeIdentityServiceCall(ctx, toznySDK, http.MethodPut, realmAuthenticationFlowPath(realmName, d.Id()), realmAuthenticationFlow{
+			ID:          d.Id(),
+			Alias:       alias,
+			Description: d.Get("description").(string),
+			ProviderID:  d.Get("provider_id").(string),
+			TopLevel:    true,
+		}, nil)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	if d.HasChange("realm_binding") {
+		oldBinding, newBinding := d.GetChange("realm_binding")
+		if oldBinding.(string) != "" {
+			err = unbindRealmAuthenticationFlow(ctx, toznySDK, realmName, oldBinding.(string), alias)
+			if err != nil {
+				return diag.FromErr(err)
+			}
+		}
+		if newBinding.(string) != "" {
+			err = bindRealmAuthenticationFlow(ctx, toznySDK, realmName, newBinding.(string), alias)
+			if err != nil {
+				return diag.FromErr(err)
+			}
+		}
+	}
+
+	if d.HasChange("application_override") {
+		oldOverrides, newOverrides := d.GetChange("application_override")
+		err = updateRealmAuthenticationFlowOverrides(ctx, toznySDK, realmName, d.Id(), oldOverrides.(*schema.Set).Difference(newOverrides.(*schema.Set)).List(), newOverrides.(*schema.Set).Difference(oldOverrides.(*schema.Set)).List())
+		if err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	return resourceRealmAuthenticationFlowRead(ctx, d, m)
+}
+
+func resourceRealmAuthenticationFlowDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	toznySDK, err := MakeToznySDK(d, m)
+
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	realmName := d.Get("realm_name").(string)
+
+	// Flows that are in use can't be deleted so return the realm and applications to their default flows first
+	if binding := d.Get("realm_binding").(string); binding != "" {
+		err = unbindRealmAuthenticationFlow(ctx, toznySDK, realmName, binding, d.Get("alias").(string))
+		if err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	err = updateRealmAuthenticationFlowOverrides(ctx, toznySDK, realmName, d.Id(), d.Get("application_override").(*schema.Set).List(), nil)
+
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	err = makeIdentityServiceCall(ctx, toznySDK, http.MethodDelete, realmAuthenticationFlowPath(realmName, d.Id()), nil, nil)
+
+	if err != nil && !IsServiceCallNotFound(err) {
+		return diag.FromErr(err)
+	}
+
+	d.SetId("")
+
+	return diags
+}
+
+// resourceRealmAuthenticationFlowImport imports an authentication flow using an import ID of the form `realm_name/flow_id`.
+func resourceRealmAuthenticationFlowImport(ctx context.Context, d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+	realmName, flowID, err := ParseRealmScopedImportID(d.Id())
+	if err != nil {
+		return nil, err
+	}
+
+	toznySDK, err := MakeToznySDK(d, m)
+	if err != nil {
+		return nil, err
+	}
+
+	settings, err := describeRealmSettings(ctx, toznySDK, realmName)
+	if err != nil {
+		return nil, err
+	}
+
+	var flow realmAuthenticationFlow
+	err = makeIdentityServiceCall(ctx, toznySDK, http.MethodGet, realmAuthenticationFlowPath(realmName, flowID), nil, &flow)
+	if err != nil {
+		return nil, err
+	}
+
+	// Application overrides aren't discoverable from the flow so only the realm binding is imported
+	realmBinding := ""
+	for binding, bindingSetting := range realmFlowBindings {
+		if stringSetting(*bindingSetting.setting(settings)) == flow.Alias {
+			realmBinding = binding
+		}
+	}
+
+	d.SetId(flowID)
+	d.Set("realm_name", realmName)
+	d.Set("realm_binding", realmBinding)
+	d.Set("copy_from", "")
+	d.Set("client_credentials_filepath", "")
+	d.Set("client_credentials_config", "")
+
+	return []*schema.ResourceData{d}, nil
+}
+
+// bindRealmAuthenticationFlow sets the flow with the given alias as the realm's flow for the named binding, returning error (if any).
+func bindRealmAuthenticationFlow(ctx context.Context, toznySDK *e3db.ToznySDKV3, realmName string, binding string, alias string) error {
+	var settings realmSettings
+	*realmFlowBindings[binding].setting(&settings) = &alias
+
+	return updateRealmSettings(ctx, toznySDK, realmName, settings)
+}
+
+// unbindRealmAuthenticationFlow returns the named realm binding to the service default flow
+// if the flow with the given alias is still bound to it, returning error (if any).
+func unbindRealmAuthenticationFlow(ctx context.Context, toznySDK *e3db.ToznySDKV3, realmName string, binding string, alias string) error {
+	settings, err := describeRealmSettings(ctx, toznySDK, realmName)
+	if err != nil {
+		return err
+	}
+
+	if stringSetting(*realmFlowBindings[binding].setting(settings)) != alias {
+		return nil
+	}
+
+	return bindRealmAuthenticationFlow(ctx, toznySDK, realmName, binding, realmFlowBindings[binding].defaultAlias)
+}
+
+// updateRealmAuthenticationFlowOverrides removes and then adds application overrides of a flow, returning error (if any).
+func updateRealmAuthenticationFlowOverrides(ctx context.Context, toznySDK *e3db.ToznySDKV3, realmName string, flowID string, removedOverrides []interface{}, addedOverrides []interface{}) error {
+	for _, override := range removedOverrides {
+		override := override.(map[string]interface{})
+		applicationID := override["application_id"].(string)
+		binding := override["binding"].(string)
+		applicationOverrides, err := listRealmApplicationFlowOverrides(ctx, toznySDK, realmName, applicationID)
+		if err != nil {
+			if IsServiceCallNotFound(err) {
+				continue
+			}
+			return err
+		}
+		// Leave overrides since pointed at another flow alone
+		if applicationOverrides[binding] != flowID {
+			continue
+		}
+		err = updateRealmApplicationFlowOverrides(ctx, toznySDK, realmName, applicationID, map[string]string{
+			binding: "",
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	for _, override := range addedOverrides {
+		override := override.(map[string]interface{})
+		err := updateRealmApplicationFlowOverrides(ctx, toznySDK, realmName, override["application_id"].(string), map[string]string{
+			override["binding"].(string): flowID,
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -8,6 +8,7 @@ import (
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 	"github.com/tozny/e3db-clients-go/identityClient"
+	"github.com/tozny/e3db-go/v2"
 )
 
 // resourceRealmBrokerIdentity returns the schema and methods for provisioning a Tozny Client Registration Token.
@@ -23,7 +24,36 @@ func resourceRealmBrokerIdentity() *schema.Resource {
 				Default:      "file",
 				Optional:     true,
 				ForceNew:     true,
-				ValidateFunc: validation.StringInSlice([]string{"file", "terraform"}, false),
+				ValidateFunc: validation.StringInSlice([]string{"file", "terraform", "tozstore"}, false),
+			},
+			"credentials_vault_client_credentials_filepath": {
+				Description:   "The filepath to Tozny client credentials for the client that will own the TozStore record the broker identity credentials are persisted to when persist_credentials_to is set to 'tozstore'. Defaults to the client used to provision this brokering Identity.",
+				Type:          schema.TypeString,
+				Optional:      true,
+				Default:       "",
+				ForceNew:      true,
+				ConflictsWith: []string{"credentials_vault_client_credentials_config"},
+			},
+			"credentials_vault_client_credentials_config": {
+				Description:   "The Tozny client configuration as a JSON string for the client that will own the TozStore record the broker identity credentials are persisted to when persist_credentials_to is set to 'tozstore'. Defaults to the client used to provision this brokering Identity.",
+				Type:          schema.TypeString,
+				Optional:      true,
+				Default:       "",
+				ForceNew:      true,
+				Sensitive:     true,
+				ConflictsWith: []string{"credentials_vault_client_credentials_filepath"},
+			},
+			"credentials_record_type": {
+				Description: "The type of the TozStore record the broker identity credentials are persisted to when persist_credentials_to is set to 'tozstore'.",
+				Type:        schema.TypeString,
+				Optional:    true,
+				Default:     "tozny.terraform.broker.credentials",
+				ForceNew:    true,
+			},
+			"credentials_record_id": {
+				Description: "ID of the TozStore record containing the broker identity credentials. Only populated when persist_credentials_to is set to 'tozstore'.",
+				Type:        schema.TypeString,
+				Computed:    true,
 			},
 			"client_registration_token": {
 				Description: "Token to use when registering the Identity's client.",
@@ -102,6 +132,15 @@ func resourceRealmBrokerIdentityCreate(ctx context.Context, d *schema.ResourceDa
 		RealmName:               realmName,
 	}
 
+	// Build the vault SDK before registering the identity so bad vault credentials can't orphan it
+	var vaultSDK *e3db.ToznySDKV3
+	if persistTo == "tozstore" {
+		vaultSDK, err = makeRealmBrokerIdentityVaultSDK(d, toznySDK)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
 	brokerIdentity, secretKeys, err := MakeToznyBrokerIdentity(brokerIdentityConfig)
 
 	if err != nil {
@@ -129,14 +168,31 @@ func resourceRealmBrokerIdentityCreate(ctx context.Context, d *schema.ResourceDa
 		secretKeys.PrivateSigningKey.Type: secretKeys.PrivateSigningKey.Material,
 	}
 
-	if persistTo == "file" {
+	switch persistTo {
+	case "file":
 		err = SaveToznyBrokerIdentity(d.Get("broker_identity_credentials_save_filepath").(string), registeredBrokerIdentity.Identity)
 
 		if err != nil {
 			return diag.FromErr(err)
 		}
 		d.Set("credentials", "")
-	} else {
+	case "tozstore":
+		clientCredentialsJSONBytes, err := json.Marshal(registeredBrokerIdentity.Identity)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+
+		recordID, err := WriteToznyCredentialsRecord(ctx, vaultSDK, d.Get("credentials_record_type").(string), string(clientCredentialsJSONBytes))
+		if err != nil {
+			// Keep the registered identity and its credentials in state rather than losing both
+			d.Set("credentials", string(clientCredentialsJSONBytes))
+			d.SetId(realmBrokerIdentityID)
+			return diag.Errorf("Broker identity %q was registered but its credentials could not be written to TozStore and have been kept in the %q attribute instead: %+v", realmBrokerIdentityID, "credentials", err)
+		}
+
+		d.Set("credentials_record_id", recordID)
+		d.Set("credentials", "")
+	default:
 		clientCredentialsJSONBytes, err := json.Marshal(registeredBrokerIdentity.Identity)
 		if err != nil {
 			return diag.FromErr(err)
@@ -161,8 +217,39 @@ func resourceRealmBrokerIdentityRead(ctx context.Context, d *schema.ResourceData
 func resourceRealmBrokerIdentityDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	var diags diag.Diagnostics
 
+	// Don't leave the identity's credentials behind in TozStore
+	if recordID := d.Get("credentials_record_id").(string); d.Get("persist_credentials_to").(string) == "tozstore" && recordID != "" {
+		toznySDK, err := MakeToznySDK(d, m)
+
+		if err != nil {
+			return diag.FromErr(err)
+		}
+
+		vaultSDK, err := makeRealmBrokerIdentityVaultSDK(d, toznySDK)
+
+		if err != nil {
+			return diag.FromErr(err)
+		}
+
+		err = DeleteToznyCredentialsRecord(ctx, vaultSDK, recordID)
+
+		if err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
 	// Soft delete (from Terraform state only) as Identity deletion is not currently supported by the Tozny API
 	d.SetId("")
 
 	return diags
 }
+
+// makeRealmBrokerIdentityVaultSDK returns the SDK for the client owning the identity's TozStore credentials record,
+// which is the provisioning client unless vault client credentials are set, and error (if any).
+func makeRealmBrokerIdentityVaultSDK(d *schema.ResourceData, toznySDK *e3db.ToznySDKV3) (*e3db.ToznySDKV3, error) {
+	vaultCredentialsFilepath, vaultCredentialsConfig := d.Get("credentials_vault_client_credentials_filepath").(string), d.Get("credentials_vault_client_credentials_config").(string)
+	if vaultCredentialsFilepath == "" && vaultCredentialsConfig == "" {
+		return toznySDK, nil
+	}
+	return MakeToznyVaultSDK(vaultCredentialsFilepath, vaultCredentialsConfig)
+}
@@ -0,0 +1,196 @@
+package tozny
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/tozny/e3db-go/v2"
+)
+
+// resourceRealmBruteForceProtection returns the schema and methods for configuring the brute force detection of a Tozny Realm
+func resourceRealmBruteForceProtection() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceRealmBruteForceProtectionCreateOrUpdate,
+		ReadContext:   resourceRealmBruteForceProtectionRead,
+		UpdateContext: resourceRealmBruteForceProtectionCreateOrUpdate,
+		DeleteContext: resourceRealmBruteForceProtectionDelete,
+		CustomizeDiff: ForceNewUnlessRealmRenamed,
+		Importer: &schema.ResourceImporter{
+			StateContext: importRealmSettingsResource,
+		},
+		Schema: map[string]*schema.Schema{
+			"client_credentials_filepath": {
+				Description:   "The filepath to Tozny client credentials for the provider to use when provisioning this brute force protection.",
+				Type:          schema.TypeString,
+				Optional:      true,
+				Default:       "",
+				ForceNew:      true,
+				ConflictsWith: []string{"client_credentials_config"},
+			},
+			"client_credentials_config": {
+				Description:   "The Tozny account client configuration as a JSON string",
+				Type:          schema.TypeString,
+				Optional:      true,
+				Default:       "",
+				ForceNew:      true,
+				Sensitive:     true,
+				ConflictsWith: []string{"client_credentials_filepath"},
+			},
+			"realm_name": {
+				Description: "Name of the realm to protect.",
+				Type:        schema.TypeString,
+				Required:    true,
+			},
+			"realm_id": RealmIDSchema(),
+			"enabled": {
+				Description: "Whether identities are temporarily locked out after repeated login failures.",
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     true,
+			},
+			"max_login_failures": {
+				Description:  "Number of login failures before an identity is locked out.",
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      30,
+				ValidateFunc: validation.IntAtLeast(1),
+			},
+			"wait_increment_seconds": {
+				Description:  "Seconds an identity is locked out for each time `max_login_failures` is reached.",
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      60,
+				ValidateFunc: validation.IntAtLeast(0),
+			},
+			"max_wait_seconds": {
+				Description:  "Maximum seconds an identity can be temporarily locked out for.",
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      900,
+				ValidateFunc: validation.IntAtLeast(0),
+			},
+			"failure_reset_time_seconds": {
+				Description:  "Seconds after which the login failure count of an identity is reset.",
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      43200,
+				ValidateFunc: validation.IntAtLeast(0),
+			},
+			"quick_login_check_milliseconds": {
+				Description:  "If login failures happen more often than this many milliseconds the identity is locked out for `minimum_quick_login_wait_seconds`.",
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      1000,
+				ValidateFunc: validation.IntAtLeast(0),
+			},
+			"minimum_quick_login_wait_seconds": {
+				Description:  "Seconds an identity is locked out for after quick successive login failures.",
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      60,
+				ValidateFunc: validation.IntAtLeast(0),
+			},
+			"permanent_lockout": {
+				Description: "Whether identities are disabled, rather than temporarily locked out, once `max_login_failures` is reached.",
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+			},
+		},
+	}
+}
+
+func resourceRealmBruteForceProtectionCreateOrUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	return createOrUpdateRealmSettingsResource(ctx, d, m, applyRealmBruteForceProtection, resourceRealmBruteForceProtectionRead)
+}
+
+// applyRealmBruteForceProtection applies the configured brute force detection settings to the named realm, returning error (if any).
+func applyRealmBruteForceProtection(ctx context.Context, toznySDK *e3db.ToznySDKV3, d *schema.ResourceData, realmName string) error {
+	enabled := d.Get("enabled").(bool)
+	maxLoginFailures := d.Get("max_login_failures").(int)
+	waitIncrementSeconds := d.Get("wait_increment_seconds").(int)
+	maxWaitSeconds := d.Get("max_wait_seconds").(int)
+	failureResetTimeSeconds := d.Get("failure_reset_time_seconds").(int)
+	quickLoginCheckMilliseconds := d.Get("quick_login_check_milliseconds").(int)
+	minimumQuickLoginWaitSeconds := d.Get("minimum_quick_login_wait_seconds").(int)
+	permanentLockout := d.Get("permanent_lockout").(bool)
+
+	return updateRealmSettings(ctx, toznySDK, realmName, realmSettings{
+		BruteForceProtected:          &enabled,
+		FailureFactor:                &maxLoginFailures,
+		WaitIncrementSeconds:         &waitIncrementSeconds,
+		MaxFailureWaitSeconds:        &maxWaitSeconds,
+		MaxDeltaTimeSeconds:          &failureResetTimeSeconds,
+		QuickLoginCheckMilliSeconds:  &quickLoginCheckMilliseconds,
+		MinimumQuickLoginWaitSeconds: &minimumQuickLoginWaitSeconds,
+		PermanentLockout:             &permanentLockout,
+	})
+}
+
+func resourceRealmBruteForceProtectionRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	toznySDK, err := MakeToznySDK(d, m)
+
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	// Look the realm up by ID so the resource can still be read after the realm is renamed
+	realmExists, err := readRealmSettingsResourceRealm(ctx, toznySDK, d)
+
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	if !realmExists {
+		d.SetId("")
+		return diags
+	}
+
+	realmName := d.Get("realm_name").(string)
+
+	settings, err := describeRealmSettings(ctx, toznySDK, realmName)
+
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.Set("enabled", boolSetting(settings.BruteForceProtected))
+	d.Set("max_login_failures", intSetting(settings.FailureFactor))
+	d.Set("wait_increment_seconds", intSetting(settings.WaitIncrementSeconds))
+	d.Set("max_wait_seconds", intSetting(settings.MaxFailureWaitSeconds))
+	d.Set("failure_reset_time_seconds", intSetting(settings.MaxDeltaTimeSeconds))
+	d.Set("quick_login_check_milliseconds", intSetting(settings.QuickLoginCheckMilliSeconds))
+	d.Set("minimum_quick_login_wait_seconds", intSetting(settings.MinimumQuickLoginWaitSeconds))
+	d.Set("permanent_lockout", boolSetting(settings.PermanentLockout))
+
+	return diags
+}
+
+func resourceRealmBruteForceProtectionDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	toznySDK, err := MakeToznySDK(d, m)
+
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	// Turn detection off rather than back to defaults so no identity stays locked out once Terraform stops managing it
+	disabled := false
+	err = updateRealmSettings(ctx, toznySDK, d.Get("realm_name").(string), realmSettings{
+		BruteForceProtected: &disabled,
+		PermanentLockout:    &disabled,
+	})
+
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId("")
+
+	return diags
+}
@@ -0,0 +1,194 @@
+package tozny
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/tozny/e3db-go/v2"
+)
+
+// resourceRealmEvents returns the schema and methods for configuring the capture of login and admin events for a Tozny Realm
+func resourceRealmEvents() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceRealmEventsCreateOrUpdate,
+		ReadContext:   resourceRealmEventsRead,
+		UpdateContext: resourceRealmEventsCreateOrUpdate,
+		DeleteContext: resourceRealmEventsDelete,
+		CustomizeDiff: ForceNewUnlessRealmRenamed,
+		Importer: &schema.ResourceImporter{
+			StateContext: importRealmSettingsResource,
+		},
+		Schema: map[string]*schema.Schema{
+			"client_credentials_filepath": {
+				Description:   "The filepath to Tozny client credentials for the provider to use when provisioning this events configuration.",
+				Type:          schema.TypeString,
+				Optional:      true,
+				Default:       "",
+				ForceNew:      true,
+				ConflictsWith: []string{"client_credentials_config"},
+			},
+			"client_credentials_config": {
+				Description:   "The Tozny account client configuration as a JSON string",
+				Type:          schema.TypeString,
+				Optional:      true,
+				Default:       "",
+				ForceNew:      true,
+				Sensitive:     true,
+				ConflictsWith: []string{"client_credentials_filepath"},
+			},
+			"realm_name": {
+				Description: "Name of the realm to capture events for.",
+				Type:        schema.TypeString,
+				Required:    true,
+			},
+			"realm_id": RealmIDSchema(),
+			"events_enabled": {
+				Description: "Whether login events are saved.",
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     true,
+			},
+			"events_expiration_seconds": {
+				Description:  "Seconds saved login events are kept for. `0` to keep events forever.",
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      0,
+				ValidateFunc: validation.IntAtLeast(0),
+			},
+			"enabled_event_types": {
+				Description: "Types of login events to save, e.g. `LOGIN` or `LOGIN_ERROR`. Defaults to the service's default set of event types.",
+				Type:        schema.TypeSet,
+				Optional:    true,
+				Computed:    true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"admin_events_enabled": {
+				Description: "Whether admin events are saved.",
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     true,
+			},
+			"admin_events_include_representation": {
+				Description: "Whether saved admin events include the JSON representation of the request.",
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+			},
+			"event_listeners": {
+				Description: "Listeners that receive login and admin events, e.g. `jboss-logging`. Defaults to the service's default listeners.",
+				Type:        schema.TypeSet,
+				Optional:    true,
+				Computed:    true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+		},
+	}
+}
+
+func resourceRealmEventsCreateOrUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	return createOrUpdateRealmSettingsResource(ctx, d, m, applyRealmEvents, resourceRealmEventsRead)
+}
+
+// applyRealmEvents applies the configured event recording settings to the named realm, returning error (if any).
+func applyRealmEvents(ctx context.Context, toznySDK *e3db.ToznySDKV3, d *schema.ResourceData, realmName string) error {
+	// Start from the current config so event types and listeners that
+	// are left unset keep the values the realm already has
+	config, err := describeRealmEventsConfig(ctx, toznySDK, realmName)
+
+	if err != nil {
+		return err
+	}
+
+	config.EventsEnabled = d.Get("events_enabled").(bool)
+	config.EventsExpiration = d.Get("events_expiration_seconds").(int)
+	config.AdminEventsEnabled = d.Get("admin_events_enabled").(bool)
+	config.AdminEventsDetailsEnabled = d.Get("admin_events_include_representation").(bool)
+
+	if eventTypes, ok := d.GetOk("enabled_event_types"); ok {
+		config.EnabledEventTypes = SchemaToStringSlice(eventTypes.(*schema.Set).List())
+	}
+	if listeners, ok := d.GetOk("event_listeners"); ok {
+		config.EventsListeners = SchemaToStringSlice(listeners.(*schema.Set).List())
+	}
+
+	return updateRealmEventsConfig(ctx, toznySDK, realmName, *config)
+}
+
+func resourceRealmEventsRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	toznySDK, err := MakeToznySDK(d, m)
+
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	// Look the realm up by ID so the resource can still be read after the realm is renamed
+	realmExists, err := readRealmSettingsResourceRealm(ctx, toznySDK, d)
+
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	if !realmExists {
+		d.SetId("")
+		return diags
+	}
+
+	realmName := d.Get("realm_name").(string)
+
+	config, err := describeRealmEventsConfig(ctx, toznySDK, realmName)
+
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.Set("events_enabled", config.EventsEnabled)
+	d.Set("events_expiration_seconds", config.EventsExpiration)
+	d.Set("enabled_event_types", config.EnabledEventTypes)
+	d.Set("admin_events_enabled", config.AdminEventsEnabled)
+	d.Set("admin_events_include_representation", config.AdminEventsDetailsEnabled)
+	d.Set("event_listeners", config.EventsListeners)
+
+	return diags
+}
+
+func resourceRealmEventsDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	toznySDK, err := MakeToznySDK(d, m)
+
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	realmName := d.Get("realm_name").(string)
+
+	config, err := describeRealmEventsConfig(ctx, toznySDK, realmName)
+
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	// Stop saving events but keep the expiration and listeners, so events
+	// already saved still expire as previously configured
+	config.EventsEnabled = false
+	config.AdminEventsEnabled = false
+	config.AdminEventsDetailsEnabled = false
+
+	err = updateRealmEventsConfig(ctx, toznySDK, realmName, *config)
+
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId("")
+
+	return diags
+}
@@ -2,6 +2,7 @@ package tozny
 
 import (
 	"context"
+	"sort"
 	"strings"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
@@ -16,6 +17,10 @@ func resourceRealmGroup() *schema.Resource {
 		ReadContext:   resourceRealmGroupRead,
 		DeleteContext: resourceRealmGroupDelete,
 		UpdateContext: resourceRealmGroupUpdate,
+		CustomizeDiff: ForceNewUnlessRealmRenamed,
+		Importer: &schema.ResourceImporter{
+			StateContext: resourceRealmGroupImport,
+		},
 		Schema: map[string]*schema.Schema{
 			"client_credentials_filepath": {
 				Description:   "The filepath to Tozny client credentials for the Terraform provider to use when provisioning this realm provider.",
@@ -38,8 +43,8 @@ func resourceRealmGroup() *schema.Resource {
 				Description: "The name of the Realm to provision the group for.",
 				Type:        schema.TypeString,
 				Required:    true,
-				ForceNew:    true,
 			},
+			"realm_id": RealmIDSchema(),
 			"name": {
 				Description: "Human readable/reference-able name for the group.",
 				Type:        schema.TypeString,
@@ -271,6 +276,18 @@ func resourceRealmGroupRead(ctx context.Context, d *schema.ResourceData, m inter
 		return diag.FromErr(err)
 	}
 
+	// Look the realm up by ID so the resource can still be read after the realm is renamed
+	realmExists, err := ReadRealmIntoState(ctx, toznySDK, d)
+
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	if !realmExists {
+		d.SetId("")
+		return diags
+	}
+
 	group, err := toznySDK.DescribeRealmGroup(ctx, identityClient.DescribeRealmGroupRequest{
 		RealmName: strings.ToLower(d.Get("realm_name").(string)),
 		GroupID:   d.Get("group_id").(string),
@@ -358,6 +375,13 @@ func resourceRealmGroupUpdate(ctx context.Context, d *schema.ResourceData, m int
 		return diag.FromErr(err)
 	}
 
+	// Only renames of the realm are updated in place, pointing the resource at a different realm replaces it
+	err = CheckRealmRenamed(ctx, toznySDK, d)
+
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
 	// Check if any relevant properties have changed
 	if d.HasChanges("access_policy") {
 		// Update the access policy. Currently we only update plugin information.
@@ -403,12 +427,35 @@ func attributesFromState(d *schema.ResourceData) map[string][]string {
 }
 
 func attributesToState(attributes map[string][]string) []interface{} {
+	// Order attributes by key so reading them back does not reorder the list
+	keys := make([]string, 0, len(attributes))
+	for key := range attributes {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
 	var stateAttributes []interface{}
-	for key, values := range attributes {
+	for _, key := range keys {
 		attrMap := map[string]interface{}{}
 		attrMap["key"] = key
-		attrMap["values"] = values
+		attrMap["values"] = attributes[key]
 		stateAttributes = append(stateAttributes, attrMap)
 	}
 	return stateAttributes
 }
+
+// resourceRealmGroupImport imports a realm group using an import ID of the form `realm_name/group_id`.
+func resourceRealmGroupImport(ctx context.Context, d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+	realmName, groupID, err := ParseRealmScopedImportID(d.Id())
+	if err != nil {
+		return nil, err
+	}
+
+	d.SetId(groupID)
+	d.Set("realm_name", realmName)
+	d.Set("group_id", groupID)
+	d.Set("client_credentials_filepath", "")
+	d.Set("client_credentials_config", "")
+
+	return []*schema.ResourceData{d}, nil
+}
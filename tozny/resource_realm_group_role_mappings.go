@@ -15,6 +15,8 @@ func resourceRealmGroupRoleMappings() *schema.Resource {
 	return &schema.Resource{
 		CreateContext: resourceRealmGroupRoleMappingsCreate,
 		ReadContext:   resourceRealmGroupRoleMappingsRead,
+		UpdateContext: UpdateRealmRenamed(resourceRealmGroupRoleMappingsRead),
+		CustomizeDiff: ForceNewUnlessRealmRenamed,
 		DeleteContext: resourceRealmGroupRoleMappingsDelete,
 		Schema: map[string]*schema.Schema{
 			"client_credentials_filepath": {
@@ -38,8 +40,8 @@ func resourceRealmGroupRoleMappings() *schema.Resource {
 				Description: "The name of the Realm associated with the group to provision role mappings for.",
 				Type:        schema.TypeString,
 				Required:    true,
-				ForceNew:    true,
 			},
+			"realm_id": RealmIDSchema(),
 			"group_id": {
 				Description: "Server defined unique identifier for the group to provision role mappings for.",
 				Type:        schema.TypeString,
@@ -162,6 +164,18 @@ func resourceRealmGroupRoleMappingsRead(ctx context.Context, d *schema.ResourceD
 		return diag.FromErr(err)
 	}
 
+	// Look the realm up by ID so the resource can still be read after the realm is renamed
+	realmExists, err := ReadRealmIntoState(ctx, toznySDK, d)
+
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	if !realmExists {
+		d.SetId("")
+		return diags
+	}
+
 	groupRoleMappings, err := toznySDK.ListGroupRoleMappings(ctx, identityClient.ListGroupRoleMappingsRequest{
 		RealmName: strings.ToLower(d.Get("realm_name").(string)),
 		GroupID:   d.Get("group_id").(string),
@@ -18,6 +18,7 @@ func resourceRealmIdentity() *schema.Resource {
 		ReadContext:   resourceRealmIdentityRead,
 		DeleteContext: resourceRealmIdentityDelete,
 		UpdateContext: resourceRealmIdentityUpdate,
+		CustomizeDiff: ForceNewUnlessRealmRenamed,
 
 		Schema: map[string]*schema.Schema{
 			"client_credentials_filepath": {
@@ -41,8 +42,8 @@ func resourceRealmIdentity() *schema.Resource {
 				Description: "The name of the Realm to provision the identity for.",
 				Type:        schema.TypeString,
 				Required:    true,
-				ForceNew:    true,
 			},
+			"realm_id": RealmIDSchema(),
 			"username": {
 				Description: "The username for this identity",
 				Type:        schema.TypeString,
@@ -131,7 +132,26 @@ func resourceRealmIdentityCreate(ctx context.Context, d *schema.ResourceData, m
 
 func resourceRealmIdentityRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	var diags diag.Diagnostics
-	// There is nothing to read for an identity at this time, so implement as a noop.
+
+	toznySDK, err := MakeToznySDK(d, m)
+
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	// Look the realm up by ID so the resource can still be read after the realm is renamed
+	realmExists, err := ReadRealmIntoState(ctx, toznySDK, d)
+
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	if !realmExists {
+		d.SetId("")
+		return diags
+	}
+
+	// There is nothing else to read for an identity at this time.
 	return diags
 }
 
@@ -160,6 +180,13 @@ func resourceRealmIdentityDelete(ctx context.Context, d *schema.ResourceData, m
 func resourceRealmIdentityUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	var diags diag.Diagnostics
 	toznySDK, err := MakeToznySDK(d, m)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	// Only renames of the realm are updated in place, pointing the resource at a different realm replaces it
+	err = CheckRealmRenamed(ctx, toznySDK, d)
+
 	if err != nil {
 		return diag.FromErr(err)
 	}
@@ -0,0 +1,373 @@
+package tozny
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/customdiff"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+// realmKeyProvider wraps a provider of keys used by a realm to sign tokens.
+type realmKeyProvider struct {
+	ID            string `json:"id,omitempty"`
+	Name          string `json:"name"`
+	ProviderID    string `json:"provider_id"`
+	Priority      int    `json:"priority"`
+	Enabled       bool   `json:"enabled"`
+	Active        bool   `json:"active"`
+	Algorithm     string `json:"algorithm"`
+	KeySize       int    `json:"key_size,omitempty"`
+	EllipticCurve string `json:"elliptic_curve,omitempty"`
+	PrivateKey    string `json:"private_key,omitempty"`
+	Certificate   string `json:"certificate,omitempty"`
+	KeyID         string `json:"kid,omitempty"`
+	PublicKey     string `json:"public_key,omitempty"`
+}
+
+// realmKeyStates maps the state of a realm key to whether the key is enabled and active.
+var realmKeyStates = map[string][2]bool{
+	"active":   {true, true},
+	"passive":  {true, false},
+	"disabled": {false, false},
+}
+
+// realmKeyEllipticCurves maps the algorithm of an EC key to the curve it signs with.
+var realmKeyEllipticCurves = map[string]string{
+	"ES256": "P-256",
+	"ES384": "P-384",
+	"ES512": "P-521",
+}
+
+// resourceRealmKey returns the schema and methods for provisioning a Tozny Realm token signing key
+func resourceRealmKey() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceRealmKeyCreate,
+		ReadContext:   resourceRealmKeyRead,
+		UpdateContext: resourceRealmKeyUpdate,
+		DeleteContext: resourceRealmKeyDelete,
+		CustomizeDiff: customdiff.All(resourceRealmKeyCustomizeDiff, ForceNewUnlessRealmRenamed),
+		Importer: &schema.ResourceImporter{
+			StateContext: resourceRealmKeyImport,
+		},
+		Schema: map[string]*schema.Schema{
+			"client_credentials_filepath": {
+				Description:   "The filepath to Tozny client credentials for the provider to use when provisioning this key.",
+				Type:          schema.TypeString,
+				Optional:      true,
+				Default:       "",
+				ForceNew:      true,
+				ConflictsWith: []string{"client_credentials_config"},
+			},
+			"client_credentials_config": {
+				Description:   "The Tozny account client configuration as a JSON string",
+				Type:          schema.TypeString,
+				Optional:      true,
+				Default:       "",
+				ForceNew:      true,
+				Sensitive:     true,
+				ConflictsWith: []string{"client_credentials_filepath"},
+			},
+			"realm_name": {
+				Description: "Name of the realm to provision the key for.",
+				Type:        schema.TypeString,
+				Required:    true,
+			},
+			"realm_id": RealmIDSchema(),
+			"name": {
+				Description: "User defined identifier for the key.",
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+			},
+			"algorithm": {
+				Description:  "Algorithm the key signs tokens with. Valid values are `RS256`, `RS384`, `RS512`, `PS256`, `PS384`, `PS512`, `ES256`, `ES384` and `ES512`.",
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      "RS256",
+				ForceNew:     true,
+				ValidateFunc: validation.StringInSlice([]string{"RS256", "RS384", "RS512", "PS256", "PS384", "PS512", "ES256", "ES384", "ES512"}, false),
+			},
+			"key_size": {
+				Description:   "Size in bits of a generated RSA key.",
+				Type:          schema.TypeInt,
+				Optional:      true,
+				Default:       2048,
+				ForceNew:      true,
+				ValidateFunc:  validation.IntInSlice([]int{1024, 2048, 4096}),
+				ConflictsWith: []string{"private_key"},
+			},
+			"elliptic_curve": {
+				Description:  "Curve of a generated EC key, determined by the algorithm. Valid values are `P-256` for `ES256`, `P-384` for `ES384` and `P-521` for `ES512`.",
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.StringInSlice([]string{"P-256", "P-384", "P-521"}, false),
+			},
+			"private_key": {
+				Description:  "PEM encoded RSA private key to import rather than generating a key. EC keys can't be imported.",
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      "",
+				ForceNew:     true,
+				Sensitive:    true,
+				RequiredWith: []string{"certificate"},
+			},
+			"certificate": {
+				Description: "PEM encoded certificate for the imported private key. Computed for generated keys.",
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+				ForceNew:    true,
+			},
+			"priority": {
+				Description: "Priority of the key, when several keys are active the key with the highest priority signs tokens.",
+				Type:        schema.TypeInt,
+				Optional:    true,
+				Default:     100,
+			},
+			"state": {
+				Description:  "State of the key, `active` keys sign and verify tokens, `passive` keys only verify tokens and `disabled` keys are not used.",
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      "active",
+				ValidateFunc: validation.StringInSlice([]string{"active", "passive", "disabled"}, false),
+			},
+			"provider_id": {
+				Description: "Service defined type of key provider, determined by the algorithm and whether the key is imported.",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+			"key_id": {
+				Description: "Service defined identifier (`kid`) of the key in tokens and the realm's JWKS.",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+			"public_key": {
+				Description: "PEM encoded public key of the key.",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+		},
+	}
+}
+
+func resourceRealmKeyCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	toznySDK, err := MakeToznySDK(d, m)
+
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	algorithm := d.Get("algorithm").(string)
+	privateKey := d.Get("private_key").(string)
+	state := realmKeyStates[d.Get("state").(string)]
+
+	keyProvider := realmKeyProvider{
+		Name:      d.Get("name").(string),
+		Priority:  d.Get("priority").(int),
+		Enabled:   state[0],
+		Active:    state[1],
+		Algorithm: algorithm,
+	}
+
+	switch {
+	case privateKey != "":
+		keyProvider.ProviderID = "rsa"
+		keyProvider.PrivateKey = privateKey
+		keyProvider.Certificate = d.Get("certificate").(string)
+	case algorithm[0] == 'E':
+		keyProvider.ProviderID = "ecdsa-generated"
+		keyProvider.EllipticCurve = realmKeyEllipticCurves[algorithm]
+		if ellipticCurve := d.Get("elliptic_curve").(string); ellipticCurve != "" && ellipticCurve != keyProvider.EllipticCurve {
+			return diag.Errorf("algorithm %q signs with elliptic curve %q, not %q", algorithm, keyProvider.EllipticCurve, ellipticCurve)
+		}
+	default:
+		keyProvider.ProviderID = "rsa-generated"
+		keyProvider.KeySize = d.Get("key_size").(int)
+	}
+
+	realmName := d.Get("realm_name").(string)
+
+	var createdKeyProvider realmKeyProvider
+	err = makeIdentityServiceCall(ctx, toznySDK, http.MethodPost, realmKeysPath(realmName), keyProvider, &createdKeyProvider)
+
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(createdKeyProvider.ID)
+
+	return resourceRealmKeyRead(ctx, d, m)
+}
+
+func resourceRealmKeyRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	toznySDK, err := MakeToznySDK(d, m)
+
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	// Look the realm up by ID so the resource can still be read after the realm is renamed
+	realmExists, err := ReadRealmIntoState(ctx, toznySDK, d)
+
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	if !realmExists {
+		d.SetId("")
+		return diags
+	}
+
+	var keyProvider realmKeyProvider
+	err = makeIdentityServiceCall(ctx, toznySDK, http.MethodGet, realmKeyPath(d.Get("realm_name").(string), d.Id()), nil, &keyProvider)
+
+	if err != nil {
+		if IsServiceCallNotFound(err) {
+			d.SetId("")
+			return diags
+		}
+		return diag.FromErr(err)
+	}
+
+	state := "disabled"
+	for name, enabledActive := range realmKeyStates {
+		if enabledActive == [2]bool{keyProvider.Enabled, keyProvider.Active} {
+			state = name
+		}
+	}
+
+	d.Set("name", keyProvider.Name)
+	d.Set("provider_id", keyProvider.ProviderID)
+	d.Set("algorithm", keyProvider.Algorithm)
+	d.Set("priority", keyProvider.Priority)
+	d.Set("state", state)
+	d.Set("certificate", keyProvider.Certificate)
+	d.Set("key_id", keyProvider.KeyID)
+	d.Set("public_key", keyProvider.PublicKey)
+	if keyProvider.KeySize != 0 {
+		d.Set("key_size", keyProvider.KeySize)
+	}
+	if keyProvider.EllipticCurve != "" {
+		d.Set("elliptic_curve", keyProvider.EllipticCurve)
+	}
+
+	return diags
+}
+
+func resourceRealmKeyUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	toznySDK, err := MakeToznySDK(d, m)
+
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	// Only renames of the realm are updated in place, pointing the resource at a different realm replaces it
+	err = CheckRealmRenamed(ctx, toznySDK, d)
+
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	if d.HasChanges("priority", "state") {
+		// Start from the current key provider so the PUT keeps the key material it doesn't change
+		var keyProvider realmKeyProvider
+		err = makeIdentityServiceCall(ctx, toznySDK, http.MethodGet, realmKeyPath(d.Get("realm_name").(string), d.Id()), nil, &keyProvider)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+
+		if privateKey := d.Get("private_key").(string); privateKey != "" {
+			keyProvider.PrivateKey = privateKey
+		}
+		if keyProvider.Certificate == "" {
+			keyProvider.Certificate = d.Get("certificate").(string)
+		}
+
+		state := realmKeyStates[d.Get("state").(string)]
+		keyProvider.Priority = d.Get("priority").(int)
+		keyProvider.Enabled, keyProvider.Active = state[0], state[1]
+
+		err = makeIdentityServiceCall(ctx, toznySDK, http.MethodPut, realmKeyPath(d.Get("realm_name").(string), d.Id()), keyProvider, nil)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	return resourceRealmKeyRead(ctx, d, m)
+}
+
+func resourceRealmKeyDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	toznySDK, err := MakeToznySDK(d, m)
+
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	err = makeIdentityServiceCall(ctx, toznySDK, http.MethodDelete, realmKeyPath(d.Get("realm_name").(string), d.Id()), nil, nil)
+
+	if err != nil && !IsServiceCallNotFound(err) {
+		return diag.FromErr(err)
+	}
+
+	d.SetId("")
+
+	return diags
+}
+
+// resourceRealmKeyImport imports a realm key using an import ID of the form `realm_name/key_provider_id`.
+func resourceRealmKeyImport(ctx context.Context, d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+	realmName, keyProviderID, err := ParseRealmScopedImportID(d.Id())
+	if err != nil {
+		return nil, err
+	}
+
+	d.SetId(keyProviderID)
+	d.Set("realm_name", realmName)
+	d.Set("private_key", "")
+	d.Set("client_credentials_filepath", "")
+	d.Set("client_credentials_config", "")
+
+	return []*schema.ResourceData{d}, nil
+}
+
+// resourceRealmKeyCustomizeDiff rejects importing a private key for an EC algorithm, as only RSA keys can be imported,
+// and a configured elliptic curve that doesn't match the algorithm of an EC key.
+func resourceRealmKeyCustomizeDiff(ctx context.Context, d *schema.ResourceDiff, m interface{}) error {
+	algorithm, ellipticCurve := d.Get("algorithm").(string), d.Get("elliptic_curve").(string)
+	algorithmCurve, isEllipticCurveKey := realmKeyEllipticCurves[algorithm]
+
+	if isEllipticCurveKey && d.Get("private_key").(string) != "" {
+		return fmt.Errorf("only RSA private keys can be imported, algorithm %q is not supported for imported keys", algorithm)
+	}
+
+	if d.Id() != "" && !d.HasChange("elliptic_curve") {
+		return nil
+	}
+
+	if !isEllipticCurveKey || ellipticCurve == "" || !d.NewValueKnown("elliptic_curve") || ellipticCurve == algorithmCurve {
+		return nil
+	}
+
+	return fmt.Errorf("algorithm %q signs with elliptic curve %q, not %q", algorithm, algorithmCurve, ellipticCurve)
+}
+
+// realmKeysPath returns the identity service path for the key providers of the named realm.
+func realmKeysPath(realmName string) string {
+	return fmt.Sprintf("/v1/identity/realm/%s/keys", url.PathEscape(realmName))
+}
+
+// realmKeyPath returns the identity service path for a key provider of the named realm.
+func realmKeyPath(realmName string, keyProviderID string) string {
+	return fmt.Sprintf("%s/%s", realmKeysPath(realmName), url.PathEscape(keyProviderID))
+}
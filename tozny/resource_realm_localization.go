@@ -0,0 +1,318 @@
+package tozny
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"sort"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/tozny/e3db-go/v2"
+)
+
+// realmLocalizationMessage wraps the text of a single localized message override.
+type realmLocalizationMessage struct {
+	Value string `json:"value"`
+}
+
+// resourceRealmLocalization returns the schema and methods for configuring the languages and message overrides of a Tozny Realm
+func resourceRealmLocalization() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceRealmLocalizationCreateOrUpdate,
+		ReadContext:   resourceRealmLocalizationRead,
+		UpdateContext: resourceRealmLocalizationCreateOrUpdate,
+		DeleteContext: resourceRealmLocalizationDelete,
+		CustomizeDiff: ForceNewUnlessRealmRenamed,
+		Importer: &schema.ResourceImporter{
+			StateContext: importRealmSettingsResource,
+		},
+		Schema: map[string]*schema.Schema{
+			"client_credentials_filepath": {
+				Description:   "The filepath to Tozny client credentials for the provider to use when provisioning this localization.",
+				Type:          schema.TypeString,
+				Optional:      true,
+				Default:       "",
+				ForceNew:      true,
+				ConflictsWith: []string{"client_credentials_config"},
+			},
+			"client_credentials_config": {
+				Description:   "The Tozny account client configuration as a JSON string",
+				Type:          schema.TypeString,
+				Optional:      true,
+				Default:       "",
+				ForceNew:      true,
+				Sensitive:     true,
+				ConflictsWith: []string{"client_credentials_filepath"},
+			},
+			"realm_name": {
+				Description: "Name of the realm to localize.",
+				Type:        schema.TypeString,
+				Required:    true,
+			},
+			"realm_id": RealmIDSchema(),
+			"internationalization_enabled": {
+				Description: "Whether identities can choose between the supported locales.",
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     true,
+			},
+			"supported_locales": {
+				Description: "Locales identities of the realm can choose between, e.g. `en` or `de`.",
+				Type:        schema.TypeSet,
+				Required:    true,
+				MinItems:    1,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"default_locale": {
+				Description: "Locale used when an identity has not chosen one. Must be one of `supported_locales`.",
+				Type:        schema.TypeString,
+				Required:    true,
+			},
+			"locale_messages": {
+				Description: "Overrides for the text of the realm's messages in a locale.",
+				Type:        schema.TypeList,
+				Optional:    true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"locale": {
+							Description: "Locale of the message overrides.",
+							Type:        schema.TypeString,
+							Required:    true,
+						},
+						"messages": {
+							Description: "Map of message key to the text to use for the message in the locale.",
+							Type:        schema.TypeMap,
+							Required:    true,
+							Elem: &schema.Schema{
+								Type: schema.TypeString,
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func resourceRealmLocalizationCreateOrUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	return createOrUpdateRealmSettingsResource(ctx, d, m, applyRealmLocalization, resourceRealmLocalizationRead)
+}
+
+// applyRealmLocalization applies the configured locales to the named realm and updates the message overrides
+// that changed, returning error (if any).
+func applyRealmLocalization(ctx context.Context, toznySDK *e3db.ToznySDKV3, d *schema.ResourceData, realmName string) error {
+	internationalizationEnabled := d.Get("internationalization_enabled").(bool)
+	supportedLocales := SchemaToStringSlice(d.Get("supported_locales").(*schema.Set).List())
+	defaultLocale := d.Get("default_locale").(string)
+
+	var defaultLocaleSupported bool
+	for _, supportedLocale := range supportedLocales {
+		if supportedLocale == defaultLocale {
+			defaultLocaleSupported = true
+			break
+		}
+	}
+	if !defaultLocaleSupported {
+		return fmt.Errorf("default_locale %q must be one of supported_locales", defaultLocale)
+	}
+
+	err := updateRealmSettings(ctx, toznySDK, realmName, realmSettings{
+		InternationalizationEnabled: &internationalizationEnabled,
+		SupportedLocales:            &supportedLocales,
+		DefaultLocale:               &defaultLocale,
+	})
+
+	if err != nil {
+		return err
+	}
+
+	oldLocaleMessages, newLocaleMessages := d.GetChange("locale_messages")
+	previousMessages := localeMessagesFromSchema(oldLocaleMessages.([]interface{}))
+	desiredMessages := localeMessagesFromSchema(newLocaleMessages.([]interface{}))
+
+	// Only the message keys that changed are updated so overrides are applied key by key
+	for locale, messages := range desiredMessages {
+		for key, value := range messages {
+			if previousValue, ok := previousMessages[locale][key]; ok && previousValue == value {
+				continue
+			}
+			err = makeIdentityServiceCall(ctx, toznySDK, http.MethodPut, realmLocalizationMessagePath(realmName, locale, key), realmLocalizationMessage{
+				Value: value,
+			}, nil)
+			if err != nil {
+				return err
+			}
+		}
+	}
+	for locale, messages := range previousMessages {
+		for key := range messages {
+			if _, ok := desiredMessages[locale][key]; ok {
+				continue
+			}
+			err = makeIdentityServiceCall(ctx, toznySDK, http.MethodDelete, realmLocalizationMessagePath(realmName, locale, key), nil, nil)
+			if err != nil && !IsServiceCallNotFound(err) {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+func resourceRealmLocalizationRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	toznySDK, err := MakeToznySDK(d, m)
+
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	// Look the realm up by ID so the resource can still be read after the realm is renamed
+	realmExists, err := readRealmSettingsResourceRealm(ctx, toznySDK, d)
+
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	if !realmExists {
+		d.SetId("")
+		return diags
+	}
+
+	realmName := d.Get("realm_name").(string)
+
+	settings, err := describeRealmSettings(ctx, toznySDK, realmName)
+
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	var supportedLocales []string
+	if settings.SupportedLocales != nil {
+		supportedLocales = *settings.SupportedLocales
+	}
+
+	// Read back locales in the order they are kept in state so that changes
+	// to overrides show up as per key diffs, followed by any other locales
+	// that have overrides in sorted order
+	var locales []string
+	seenLocales := map[string]bool{}
+	for _, rawLocaleMessages := range d.Get("locale_messages").([]interface{}) {
+		locale := rawLocaleMessages.(map[string]interface{})["locale"].(string)
+		if !seenLocales[locale] {
+			locales = append(locales, locale)
+			seenLocales[locale] = true
+		}
+	}
+	var otherLocales []string
+	for _, locale := range supportedLocales {
+		if !seenLocales[locale] {
+			otherLocales = append(otherLocales, locale)
+			seenLocales[locale] = true
+		}
+	}
+	sort.Strings(otherLocales)
+
+	localeMessages := []interface{}{}
+	for index, locale := range append(locales, otherLocales...) {
+		messages, err := listRealmLocalizationMessages(ctx, toznySDK, realmName, locale)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		if index >= len(locales) && len(messages) == 0 {
+			continue
+		}
+		localeMessages = append(localeMessages, map[string]interface{}{
+			"locale":   locale,
+			"messages": messages,
+		})
+	}
+
+	d.Set("realm_name", realmName)
+	d.Set("internationalization_enabled", boolSetting(settings.InternationalizationEnabled))
+	d.Set("supported_locales", supportedLocales)
+	d.Set("default_locale", stringSetting(settings.DefaultLocale))
+	d.Set("locale_messages", localeMessages)
+
+	return diags
+}
+
+func resourceRealmLocalizationDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	toznySDK, err := MakeToznySDK(d, m)
+
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	realmName := d.Get("realm_name").(string)
+
+	for locale, messages := range localeMessagesFromSchema(d.Get("locale_messages").([]interface{})) {
+		for key := range messages {
+			err = makeIdentityServiceCall(ctx, toznySDK, http.MethodDelete, realmLocalizationMessagePath(realmName, locale, key), nil, nil)
+			if err != nil && !IsServiceCallNotFound(err) {
+				return diag.FromErr(err)
+			}
+		}
+	}
+
+	internationalizationEnabled := false
+	err = updateRealmSettings(ctx, toznySDK, realmName, realmSettings{
+		InternationalizationEnabled: &internationalizationEnabled,
+	})
+
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId("")
+
+	return diags
+}
+
+// localeMessagesFromSchema converts the Terraform representation of locale message overrides
+// into a map of locale to a map of message key to message text.
+func localeMessagesFromSchema(rawLocaleMessages []interface{}) map[string]map[string]string {
+	localeMessages := map[string]map[string]string{}
+
+	for _, rawLocaleMessage := range rawLocaleMessages {
+		localeMessage := rawLocaleMessage.(map[string]interface{})
+		locale := localeMessage["locale"].(string)
+		if localeMessages[locale] == nil {
+			localeMessages[locale] = map[string]string{}
+		}
+		for key, value := range localeMessage["messages"].(map[string]interface{}) {
+			localeMessages[locale][key] = value.(string)
+		}
+	}
+
+	return localeMessages
+}
+
+// realmLocalizationPath returns the identity service path for the message overrides of the named realm in a locale.
+func realmLocalizationPath(realmName string, locale string) string {
+	return fmt.Sprintf("/v1/identity/realm/%s/localization/%s", url.PathEscape(realmName), url.PathEscape(locale))
+}
+
+// realmLocalizationMessagePath returns the identity service path for a single message override of the named realm in a locale.
+func realmLocalizationMessagePath(realmName string, locale string, key string) string {
+	return fmt.Sprintf("%s/%s", realmLocalizationPath(realmName, locale), url.PathEscape(key))
+}
+
+// listRealmLocalizationMessages fetches the message overrides of the named realm in a locale, returning error (if any).
+func listRealmLocalizationMessages(ctx context.Context, toznySDK *e3db.ToznySDKV3, realmName string, locale string) (map[string]string, error) {
+	messages := map[string]string{}
+
+	err := makeIdentityServiceCall(ctx, toznySDK, http.MethodGet, realmLocalizationPath(realmName, locale), nil, &messages)
+	if err != nil && !IsServiceCallNotFound(err) {
+		return nil, err
+	}
+
+	return messages, nil
+}
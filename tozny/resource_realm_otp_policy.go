@@ -0,0 +1,243 @@
+package tozny
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/tozny/e3db-go/v2"
+)
+
+// configureOTPRequiredActionAlias is the alias of the required action for an identity to configure a one time password generator.
+const configureOTPRequiredActionAlias = "CONFIGURE_TOTP"
+
+// resourceRealmOTPPolicy returns the schema and methods for configuring the one time password policy of a Tozny Realm
+func resourceRealmOTPPolicy() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceRealmOTPPolicyCreateOrUpdate,
+		ReadContext:   resourceRealmOTPPolicyRead,
+		UpdateContext: resourceRealmOTPPolicyCreateOrUpdate,
+		DeleteContext: resourceRealmOTPPolicyDelete,
+		CustomizeDiff: ForceNewUnlessRealmRenamed,
+		Importer: &schema.ResourceImporter{
+			StateContext: importRealmSettingsResource,
+		},
+		Schema: map[string]*schema.Schema{
+			"client_credentials_filepath": {
+				Description:   "The filepath to Tozny client credentials for the provider to use when provisioning this OTP policy.",
+				Type:          schema.TypeString,
+				Optional:      true,
+				Default:       "",
+				ForceNew:      true,
+				ConflictsWith: []string{"client_credentials_config"},
+			},
+			"client_credentials_config": {
+				Description:   "The Tozny account client configuration as a JSON string",
+				Type:          schema.TypeString,
+				Optional:      true,
+				Default:       "",
+				ForceNew:      true,
+				Sensitive:     true,
+				ConflictsWith: []string{"client_credentials_filepath"},
+			},
+			"realm_name": {
+				Description: "Name of the realm to apply the OTP policy to.",
+				Type:        schema.TypeString,
+				Required:    true,
+			},
+			"realm_id": RealmIDSchema(),
+			"type": {
+				Description:  "Type of one time password, either time based (`totp`) or counter based (`hotp`).",
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      "totp",
+				ValidateFunc: validation.StringInSlice([]string{"totp", "hotp"}, false),
+			},
+			"algorithm": {
+				Description:  "Hashing algorithm used to generate one time passwords. Valid values are `HmacSHA1`, `HmacSHA256` and `HmacSHA512`.",
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      "HmacSHA1",
+				ValidateFunc: validation.StringInSlice([]string{"HmacSHA1", "HmacSHA256", "HmacSHA512"}, false),
+			},
+			"digits": {
+				Description:  "Number of digits in a one time password, either `6` or `8`.",
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      6,
+				ValidateFunc: validation.IntInSlice([]int{6, 8}),
+			},
+			"period": {
+				Description:  "Seconds a time based one time password is valid for.",
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      30,
+				ValidateFunc: validation.IntAtLeast(1),
+			},
+			"look_ahead_window": {
+				Description:  "Number of intervals the service looks ahead of and behind the current one to tolerate clock skew or unused counters.",
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      1,
+				ValidateFunc: validation.IntAtLeast(0),
+			},
+			"initial_counter": {
+				Description:  "Initial counter value for counter based one time passwords.",
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      0,
+				ValidateFunc: validation.IntAtLeast(0),
+			},
+			"require_for_new_identities": {
+				Description: "Whether every new identity of the realm is required to configure a one time password generator on first login.",
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+			},
+		},
+	}
+}
+
+func resourceRealmOTPPolicyCreateOrUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	return createOrUpdateRealmSettingsResource(ctx, d, m, applyRealmOTPPolicy, resourceRealmOTPPolicyRead)
+}
+
+// applyRealmOTPPolicy applies the configured one time password policy to the named realm and whether new identities
+// must configure one time passwords, returning error (if any).
+func applyRealmOTPPolicy(ctx context.Context, toznySDK *e3db.ToznySDKV3, d *schema.ResourceData, realmName string) error {
+	otpType := d.Get("type").(string)
+	algorithm := d.Get("algorithm").(string)
+	digits := d.Get("digits").(int)
+	period := d.Get("period").(int)
+	lookAheadWindow := d.Get("look_ahead_window").(int)
+	initialCounter := d.Get("initial_counter").(int)
+
+	err := updateRealmSettings(ctx, toznySDK, realmName, realmSettings{
+		OTPPolicyType:            &otpType,
+		OTPPolicyAlgorithm:       &algorithm,
+		OTPPolicyDigits:          &digits,
+		OTPPolicyPeriod:          &period,
+		OTPPolicyLookAheadWindow: &lookAheadWindow,
+		OTPPolicyInitialCounter:  &initialCounter,
+	})
+
+	if err != nil {
+		return err
+	}
+
+	if d.IsNewResource() || d.HasChange("require_for_new_identities") {
+		err = requireOTPForNewIdentities(ctx, toznySDK, realmName, d.Get("require_for_new_identities").(bool))
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func resourceRealmOTPPolicyRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	toznySDK, err := MakeToznySDK(d, m)
+
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	// Look the realm up by ID so the resource can still be read after the realm is renamed
+	realmExists, err := readRealmSettingsResourceRealm(ctx, toznySDK, d)
+
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	if !realmExists {
+		d.SetId("")
+		return diags
+	}
+
+	realmName := d.Get("realm_name").(string)
+
+	settings, err := describeRealmSettings(ctx, toznySDK, realmName)
+
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	requiredAction, err := describeRealmRequiredAction(ctx, toznySDK, realmName, configureOTPRequiredActionAlias)
+
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.Set("type", stringSetting(settings.OTPPolicyType))
+	d.Set("algorithm", stringSetting(settings.OTPPolicyAlgorithm))
+	d.Set("digits", intSetting(settings.OTPPolicyDigits))
+	d.Set("period", intSetting(settings.OTPPolicyPeriod))
+	d.Set("look_ahead_window", intSetting(settings.OTPPolicyLookAheadWindow))
+	d.Set("initial_counter", intSetting(settings.OTPPolicyInitialCounter))
+	d.Set("require_for_new_identities", requiredAction.Enabled && requiredAction.DefaultAction)
+
+	return diags
+}
+
+func resourceRealmOTPPolicyDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	toznySDK, err := MakeToznySDK(d, m)
+
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	realmName := d.Get("realm_name").(string)
+
+	// The service defaults, a 6 digit TOTP every 30 seconds, match what authenticator apps assume without a QR code
+	otpType := "totp"
+	algorithm := "HmacSHA1"
+	digits := 6
+	period := 30
+	lookAheadWindow := 1
+	initialCounter := 0
+
+	err = updateRealmSettings(ctx, toznySDK, realmName, realmSettings{
+		OTPPolicyType:            &otpType,
+		OTPPolicyAlgorithm:       &algorithm,
+		OTPPolicyDigits:          &digits,
+		OTPPolicyPeriod:          &period,
+		OTPPolicyLookAheadWindow: &lookAheadWindow,
+		OTPPolicyInitialCounter:  &initialCounter,
+	})
+
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	if d.Get("require_for_new_identities").(bool) {
+		err = requireOTPForNewIdentities(ctx, toznySDK, realmName, false)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	d.SetId("")
+
+	return diags
+}
+
+// requireOTPForNewIdentities sets whether configuring a one time password generator is
+// a default required action for new identities of the named realm, returning error (if any).
+func requireOTPForNewIdentities(ctx context.Context, toznySDK *e3db.ToznySDKV3, realmName string, required bool) error {
+	requiredAction, err := describeRealmRequiredAction(ctx, toznySDK, realmName, configureOTPRequiredActionAlias)
+	if err != nil {
+		return err
+	}
+
+	if required {
+		requiredAction.Enabled = true
+	}
+	requiredAction.DefaultAction = required
+
+	return updateRealmRequiredAction(ctx, toznySDK, realmName, *requiredAction)
+}
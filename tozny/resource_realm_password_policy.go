@@ -0,0 +1,337 @@
+package tozny
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/tozny/e3db-go/v2"
+)
+
+// passwordPolicyRules maps the Terraform attributes of a password policy
+// to the rule names used in the realm's password policy.
+var passwordPolicyRules = []struct {
+	attribute string
+	rule      string
+}{
+	{"min_length", "length"},
+	{"min_lowercase_characters", "lowerCase"},
+	{"min_uppercase_characters", "upperCase"},
+	{"min_digits", "digits"},
+	{"min_special_characters", "specialChars"},
+	{"password_history", "passwordHistory"},
+	{"expiry_days", "forceExpiredPasswordChange"},
+	{"hash_iterations", "hashIterations"},
+}
+
+// passwordPolicyRuleRegex matches a single `rule(value)` term of a realm password policy.
+var passwordPolicyRuleRegex = regexp.MustCompile(`^(\w+)\((.*)\)$`)
+
+// resourceRealmPasswordPolicy returns the schema and methods for configuring the password policy of a Tozny Realm
+func resourceRealmPasswordPolicy() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceRealmPasswordPolicyCreateOrUpdate,
+		ReadContext:   resourceRealmPasswordPolicyRead,
+		UpdateContext: resourceRealmPasswordPolicyCreateOrUpdate,
+		DeleteContext: resourceRealmPasswordPolicyDelete,
+		CustomizeDiff: ForceNewUnlessRealmRenamed,
+		Importer: &schema.ResourceImporter{
+			StateContext: importRealmSettingsResource,
+		},
+		Schema: map[string]*schema.Schema{
+			"client_credentials_filepath": {
+				Description:   "The filepath to Tozny client credentials for the provider to use when provisioning this password policy.",
+				Type:          schema.TypeString,
+				Optional:      true,
+				Default:       "",
+				ForceNew:      true,
+				ConflictsWith: []string{"client_credentials_config"},
+			},
+			"client_credentials_config": {
+				Description:   "The Tozny account client configuration as a JSON string",
+				Type:          schema.TypeString,
+				Optional:      true,
+				Default:       "",
+				ForceNew:      true,
+				Sensitive:     true,
+				ConflictsWith: []string{"client_credentials_filepath"},
+			},
+			"realm_name": {
+				Description: "Name of the realm to apply the password policy to.",
+				Type:        schema.TypeString,
+				Required:    true,
+			},
+			"realm_id": RealmIDSchema(),
+			"min_length": {
+				Description:  "Minimum number of characters in a password. `0` to not enforce a minimum.",
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      0,
+				ValidateFunc: validation.IntAtLeast(0),
+			},
+			"min_lowercase_characters": {
+				Description:  "Minimum number of lower case characters in a password.",
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      0,
+				ValidateFunc: validation.IntAtLeast(0),
+			},
+			"min_uppercase_characters": {
+				Description:  "Minimum number of upper case characters in a password.",
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      0,
+				ValidateFunc: validation.IntAtLeast(0),
+			},
+			"min_digits": {
+				Description:  "Minimum number of digits in a password.",
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      0,
+				ValidateFunc: validation.IntAtLeast(0),
+			},
+			"min_special_characters": {
+				Description:  "Minimum number of special characters in a password.",
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      0,
+				ValidateFunc: validation.IntAtLeast(0),
+			},
+			"password_history": {
+				Description:  "Number of previous passwords an identity is prevented from reusing. `0` to allow reuse.",
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      0,
+				ValidateFunc: validation.IntAtLeast(0),
+			},
+			"expiry_days": {
+				Description:  "Number of days after which an identity is required to change their password. `0` for passwords that never expire.",
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      0,
+				ValidateFunc: validation.IntAtLeast(0),
+			},
+			"not_username": {
+				Description: "Whether a password is prevented from being the same as the identity's username.",
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+			},
+			"hash_iterations": {
+				Description:  "Number of hashing iterations used when storing passwords. `0` to use the service default.",
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      0,
+				ValidateFunc: validation.IntAtLeast(0),
+			},
+			"policy": {
+				Description: "The password policy as applied to the realm.",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+		},
+	}
+}
+
+func resourceRealmPasswordPolicyCreateOrUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	return createOrUpdateRealmSettingsResource(ctx, d, m, applyRealmPasswordPolicy, resourceRealmPasswordPolicyRead)
+}
+
+// applyRealmPasswordPolicy sets the password policy of the named realm to the rules configured on the resource,
+// keeping any rules set on the realm that the resource does not model, returning error (if any).
+func applyRealmPasswordPolicy(ctx context.Context, toznySDK *e3db.ToznySDKV3, d *schema.ResourceData, realmName string) error {
+	settings, err := describeRealmSettings(ctx, toznySDK, realmName)
+	if err != nil {
+		return err
+	}
+
+	policy, err := buildRealmPasswordPolicy(d, stringSetting(settings.PasswordPolicy))
+	if err != nil {
+		return err
+	}
+
+	return updateRealmSettings(ctx, toznySDK, realmName, realmSettings{
+		PasswordPolicy: &policy,
+	})
+}
+
+func resourceRealmPasswordPolicyRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	toznySDK, err := MakeToznySDK(d, m)
+
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	// Look the realm up by ID so the resource can still be read after the realm is renamed
+	realmExists, err := readRealmSettingsResourceRealm(ctx, toznySDK, d)
+
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	if !realmExists {
+		d.SetId("")
+		return diags
+	}
+
+	realmName := d.Get("realm_name").(string)
+
+	settings, err := describeRealmSettings(ctx, toznySDK, realmName)
+
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	var policy string
+	if settings.PasswordPolicy != nil {
+		policy = *settings.PasswordPolicy
+	}
+
+	rules, err := parseRealmPasswordPolicy(policy)
+
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	for _, passwordPolicyRule := range passwordPolicyRules {
+		var value int
+		if ruleValue, ok := rules[passwordPolicyRule.rule]; ok {
+			value, err = strconv.Atoi(ruleValue)
+			if err != nil {
+				return diag.Errorf("invalid value %q for password policy rule %q", ruleValue, passwordPolicyRule.rule)
+			}
+		}
+		d.Set(passwordPolicyRule.attribute, value)
+	}
+
+	_, notUsername := rules["notUsername"]
+	d.Set("not_username", notUsername)
+	d.Set("policy", policy)
+
+	return diags
+}
+
+func resourceRealmPasswordPolicyDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	toznySDK, err := MakeToznySDK(d, m)
+
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	realmName := d.Get("realm_name").(string)
+
+	settings, err := describeRealmSettings(ctx, toznySDK, realmName)
+
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	// Only remove the rules the resource manages, rules set on the realm outside of Terraform stay in force
+	unmodelledTerms, err := unmodelledPasswordPolicyTerms(stringSetting(settings.PasswordPolicy))
+
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	policy := strings.Join(unmodelledTerms, " and ")
+	err = updateRealmSettings(ctx, toznySDK, realmName, realmSettings{
+		PasswordPolicy: &policy,
+	})
+
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId("")
+
+	return diags
+}
+
+// buildRealmPasswordPolicy builds the realm password policy for the rules configured on the resource,
+// omitting any rules that are unset, followed by the rules of the current policy the resource does not model,
+// returning error (if any).
+func buildRealmPasswordPolicy(d *schema.ResourceData, currentPolicy string) (string, error) {
+	var terms []string
+
+	for _, passwordPolicyRule := range passwordPolicyRules {
+		value := d.Get(passwordPolicyRule.attribute).(int)
+		if value > 0 {
+			terms = append(terms, fmt.Sprintf("%s(%d)", passwordPolicyRule.rule, value))
+		}
+	}
+
+	if d.Get("not_username").(bool) {
+		terms = append(terms, "notUsername(undefined)")
+	}
+
+	unmodelledTerms, err := unmodelledPasswordPolicyTerms(currentPolicy)
+	if err != nil {
+		return "", err
+	}
+
+	return strings.Join(append(terms, unmodelledTerms...), " and "), nil
+}
+
+// unmodelledPasswordPolicyTerms returns the terms of a realm password policy for rules the resource
+// has no attribute for, such as regexPattern or hashAlgorithm, in policy order, returning error (if any).
+func unmodelledPasswordPolicyTerms(policy string) ([]string, error) {
+	var terms []string
+
+	if strings.TrimSpace(policy) == "" {
+		return terms, nil
+	}
+
+	for _, term := range strings.Split(policy, " and ") {
+		term = strings.TrimSpace(term)
+		matches := passwordPolicyRuleRegex.FindStringSubmatch(term)
+		if matches == nil {
+			return nil, fmt.Errorf("unable to parse password policy rule %q", term)
+		}
+		if !isModelledPasswordPolicyRule(matches[1]) {
+			terms = append(terms, term)
+		}
+	}
+
+	return terms, nil
+}
+
+// isModelledPasswordPolicyRule returns whether the named password policy rule is managed by an attribute of the resource.
+func isModelledPasswordPolicyRule(rule string) bool {
+	if rule == "notUsername" {
+		return true
+	}
+	for _, passwordPolicyRule := range passwordPolicyRules {
+		if passwordPolicyRule.rule == rule {
+			return true
+		}
+	}
+	return false
+}
+
+// parseRealmPasswordPolicy parses a realm password policy into a map of rule name to rule value, returning error (if any).
+func parseRealmPasswordPolicy(policy string) (map[string]string, error) {
+	rules := map[string]string{}
+
+	if strings.TrimSpace(policy) == "" {
+		return rules, nil
+	}
+
+	for _, term := range strings.Split(policy, " and ") {
+		matches := passwordPolicyRuleRegex.FindStringSubmatch(strings.TrimSpace(term))
+		if matches == nil {
+			return nil, fmt.Errorf("unable to parse password policy rule %q", term)
+		}
+		rules[matches[1]] = matches[2]
+	}
+
+	return rules, nil
+}
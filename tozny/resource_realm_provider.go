@@ -13,6 +13,9 @@ func resourceRealmProvider() *schema.Resource {
 	return &schema.Resource{
 		CreateContext: resourceRealmProviderCreate,
 		ReadContext:   resourceRealmProviderRead,
+		// Only deletion_protection and renames of the realm are applied in place
+		UpdateContext: UpdateRealmRenamed(resourceRealmProviderRead),
+		CustomizeDiff: ForceNewUnlessRealmRenamed,
 		DeleteContext: resourceRealmProviderDelete,
 		Schema: map[string]*schema.Schema{
 			"client_credentials_filepath": {
@@ -42,7 +45,13 @@ func resourceRealmProvider() *schema.Resource {
 				Description: "The name of the realm to associate the provider with.",
 				Type:        schema.TypeString,
 				Required:    true,
-				ForceNew:    true,
+			},
+			"realm_id": RealmIDSchema(),
+			"deletion_protection": {
+				Description: "Whether Terraform is prevented from destroying the realm provider. When enabled, it must be set to false in a prior apply before the realm provider can be destroyed or replaced.",
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
 			},
 			"name": {
 				Description: "User defined name for the provider.",
@@ -266,6 +275,18 @@ func resourceRealmProviderRead(ctx context.Context, d *schema.ResourceData, m in
 		return diag.FromErr(err)
 	}
 
+	// Look the realm up by ID so the resource can still be read after the realm is renamed
+	realmExists, err := ReadRealmIntoState(ctx, toznySDK, d)
+
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	if !realmExists {
+		d.SetId("")
+		return diags
+	}
+
 	describeProviderRequest := identityClient.DescribeRealmProviderRequest{
 		RealmName:  d.Get("realm_name").(string),
 		ProviderID: d.Get("provider_id").(string),
@@ -338,6 +359,10 @@ func resourceRealmProviderDelete(ctx context.Context, d *schema.ResourceData, m
 	var diags diag.Diagnostics
 	var err error
 
+	if protected := CheckDeletionProtection(d, "Realm provider", d.Get("name").(string)); protected != nil {
+		return protected
+	}
+
 	toznySDK, err := MakeToznySDK(d, m)
 
 	if err != nil {
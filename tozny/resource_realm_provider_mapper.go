@@ -13,6 +13,8 @@ func resourceRealmProviderMapper() *schema.Resource {
 	return &schema.Resource{
 		CreateContext: resourceRealmProviderMapperCreate,
 		ReadContext:   resourceRealmProviderMapperRead,
+		UpdateContext: UpdateRealmRenamed(resourceRealmProviderMapperRead),
+		CustomizeDiff: ForceNewUnlessRealmRenamed,
 		DeleteContext: resourceRealmProviderMapperDelete,
 		Schema: map[string]*schema.Schema{
 			"client_credentials_filepath": {
@@ -42,8 +44,8 @@ func resourceRealmProviderMapper() *schema.Resource {
 				Description: "The name of the realm to associate the provider with.",
 				Type:        schema.TypeString,
 				Required:    true,
-				ForceNew:    true,
 			},
+			"realm_id": RealmIDSchema(),
 			"provider_mapper_id": {
 				Description: "Service defined unique identifier for the provider mapper.",
 				Type:        schema.TypeString,
@@ -210,6 +212,18 @@ func resourceRealmProviderMapperRead(ctx context.Context, d *schema.ResourceData
 		return diag.FromErr(err)
 	}
 
+	// Look the realm up by ID so the resource can still be read after the realm is renamed
+	realmExists, err := ReadRealmIntoState(ctx, toznySDK, d)
+
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	if !realmExists {
+		d.SetId("")
+		return diags
+	}
+
 	describeProviderMapperRequest := identityClient.DescribeRealmProviderMapperRequest{
 		RealmName:        d.Get("realm_name").(string),
 		ProviderID:       d.Get("provider_id").(string),
@@ -0,0 +1,354 @@
+package tozny
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+const (
+	// termsAndConditionsRequiredActionAlias is the alias of the required action for an identity to accept the realm's terms and conditions.
+	termsAndConditionsRequiredActionAlias = "TERMS_AND_CONDITIONS"
+	// termsTextMessageKey is the key of the message displayed as the realm's terms and conditions.
+	termsTextMessageKey = "termsText"
+)
+
+// resourceRealmRequiredAction returns the schema and methods for configuring an action identities of a Tozny Realm are required to complete
+func resourceRealmRequiredAction() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceRealmRequiredActionCreateOrUpdate,
+		ReadContext:   resourceRealmRequiredActionRead,
+		UpdateContext: resourceRealmRequiredActionCreateOrUpdate,
+		DeleteContext: resourceRealmRequiredActionDelete,
+		CustomizeDiff: ForceNewUnlessRealmRenamed,
+		Importer: &schema.ResourceImporter{
+			StateContext: resourceRealmRequiredActionImport,
+		},
+		Schema: map[string]*schema.Schema{
+			"client_credentials_filepath": {
+				Description:   "The filepath to Tozny client credentials for the provider to use when provisioning this required action.",
+				Type:          schema.TypeString,
+				Optional:      true,
+				Default:       "",
+				ForceNew:      true,
+				ConflictsWith: []string{"client_credentials_config"},
+			},
+			"client_credentials_config": {
+				Description:   "The Tozny account client configuration as a JSON string",
+				Type:          schema.TypeString,
+				Optional:      true,
+				Default:       "",
+				ForceNew:      true,
+				Sensitive:     true,
+				ConflictsWith: []string{"client_credentials_filepath"},
+			},
+			"realm_name": {
+				Description: "Name of the realm to configure the required action for.",
+				Type:        schema.TypeString,
+				Required:    true,
+			},
+			"realm_id": RealmIDSchema(),
+			"alias": {
+				Description:  "Alias of the built-in required action, e.g. `TERMS_AND_CONDITIONS`, `VERIFY_EMAIL`, `UPDATE_PROFILE` or `UPDATE_PASSWORD`. `CONFIGURE_TOTP` is managed by `tozny_realm_otp_policy` instead.",
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validateRealmRequiredActionAlias,
+			},
+			"name": {
+				Description: "Service defined display name of the required action.",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+			"enabled": {
+				Description: "Whether identities can be required to complete the action.",
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     true,
+			},
+			"default_action": {
+				Description: "Whether every new identity of the realm is required to complete the action.",
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+			},
+			"priority": {
+				Description:  "Order in which the action is completed relative to other required actions, lower values first. Defaults to the realm's current priority for the action.",
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Computed:     true,
+				ValidateFunc: validation.IntAtLeast(0),
+			},
+			"terms_text": {
+				Description: "Text (which may include HTML) displayed as the realm's terms and conditions, stored as the realm's `termsText` message. Only valid for the `TERMS_AND_CONDITIONS` action.",
+				Type:        schema.TypeString,
+				Optional:    true,
+				Default:     "",
+			},
+			"terms_locale": {
+				Description: "Locale of `terms_text`.",
+				Type:        schema.TypeString,
+				Optional:    true,
+				Default:     "en",
+			},
+		},
+	}
+}
+
+func resourceRealmRequiredActionCreateOrUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	toznySDK, err := MakeToznySDK(d, m)
+
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	// Only renames of the realm are updated in place, pointing the resource at a different realm replaces it
+	err = CheckRealmRenamed(ctx, toznySDK, d)
+
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	if d.Get("realm_id").(int) == 0 {
+		realmExists, err := ReadRealmIntoState(ctx, toznySDK, d)
+
+		if err != nil {
+			return diag.FromErr(err)
+		}
+
+		if !realmExists {
+			return diag.Errorf("realm %q not found", d.Get("realm_name").(string))
+		}
+	}
+
+	realmName := d.Get("realm_name").(string)
+	alias := d.Get("alias").(string)
+	termsText := d.Get("terms_text").(string)
+
+	if termsText != "" && alias != termsAndConditionsRequiredActionAlias {
+		return diag.Errorf("terms_text can only be set for the %s required action", termsAndConditionsRequiredActionAlias)
+	}
+
+	requiredAction, err := describeRealmRequiredAction(ctx, toznySDK, realmName, alias)
+
+	if err != nil {
+		if IsServiceCallNotFound(err) {
+			return diag.Errorf("realm %q has no required action %q", realmName, alias)
+		}
+		return diag.FromErr(err)
+	}
+
+	requiredAction.Enabled = d.Get("enabled").(bool)
+	requiredAction.DefaultAction = d.Get("default_action").(bool)
+	if priority, ok := d.GetOk("priority"); ok {
+		requiredAction.Priority = priority.(int)
+	}
+
+	err = updateRealmRequiredAction(ctx, toznySDK, realmName, *requiredAction)
+
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	// The terms are the realm's termsText message, the same message tozny_realm_localization can override
+	if d.HasChanges("terms_text", "terms_locale") {
+		oldLocale, _ := d.GetChange("terms_locale")
+		oldText, _ := d.GetChange("terms_text")
+		if oldText.(string) != "" && oldLocale.(string) != d.Get("terms_locale").(string) {
+			err = makeIdentityServiceCall(ctx, toznySDK, http.MethodDelete, realmLocalizationMessagePath(realmName, oldLocale.(string), termsTextMessageKey), nil, nil)
+			if err != nil && !IsServiceCallNotFound(err) {
+				return diag.FromErr(err)
+			}
+		}
+		if termsText != "" {
+			err = makeIdentityServiceCall(ctx, toznySDK, http.MethodPut, realmLocalizationMessagePath(realmName, d.Get("terms_locale").(string), termsTextMessageKey), realmLocalizationMessage{
+				Value: termsText,
+			}, nil)
+		} else {
+			err = makeIdentityServiceCall(ctx, toznySDK, http.MethodDelete, realmLocalizationMessagePath(realmName, d.Get("terms_locale").(string), termsTextMessageKey), nil, nil)
+		}
+		if err != nil && !IsServiceCallNotFound(err) {
+			return diag.FromErr(err)
+		}
+	}
+
+	d.SetId(fmt.Sprintf("%d/%s", d.Get("realm_id").(int), alias))
+
+	return resourceRealmRequiredActionRead(ctx, d, m)
+}
+
+func resourceRealmRequiredActionRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	toznySDK, err := MakeToznySDK(d, m)
+
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	realmID, alias, err := parseRealmRequiredActionID(d.Id())
+
+	// Resources identified by realm name before realms could be renamed are looked up by name once
+	if err != nil {
+		realmID = d.Get("realm_id").(int)
+		alias = d.Get("alias").(string)
+	}
+
+	var realmExists bool
+	if realmID != 0 {
+		// Look the realm up by the ID the resource is keyed by so it can still be read after the realm is renamed
+		realmName, exists, err := FindRealmNameByID(ctx, toznySDK, strconv.Itoa(realmID))
+
+		if err != nil {
+			return diag.FromErr(err)
+		}
+
+		realmExists = exists
+		d.Set("realm_id", realmID)
+		// Keep the configured casing of names that only differ in case
+		if exists && !strings.EqualFold(realmName, d.Get("realm_name").(string)) {
+			d.Set("realm_name", realmName)
+		}
+	} else {
+		realmExists, err = ReadRealmIntoState(ctx, toznySDK, d)
+
+		if err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	if !realmExists {
+		d.SetId("")
+		return diags
+	}
+
+	d.SetId(fmt.Sprintf("%d/%s", d.Get("realm_id").(int), alias))
+	d.Set("alias", alias)
+
+	realmName := d.Get("realm_name").(string)
+
+	requiredAction, err := describeRealmRequiredAction(ctx, toznySDK, realmName, alias)
+
+	if err != nil {
+		if IsServiceCallNotFound(err) {
+			d.SetId("")
+			return diags
+		}
+		return diag.FromErr(err)
+	}
+
+	d.Set("name", requiredAction.Name)
+	d.Set("enabled", requiredAction.Enabled)
+	d.Set("default_action", requiredAction.DefaultAction)
+	d.Set("priority", requiredAction.Priority)
+
+	if alias == termsAndConditionsRequiredActionAlias {
+		messages, err := listRealmLocalizationMessages(ctx, toznySDK, realmName, d.Get("terms_locale").(string))
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		d.Set("terms_text", messages[termsTextMessageKey])
+	}
+
+	return diags
+}
+
+func resourceRealmRequiredActionDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	toznySDK, err := MakeToznySDK(d, m)
+
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	realmName := d.Get("realm_name").(string)
+
+	requiredAction, err := describeRealmRequiredAction(ctx, toznySDK, realmName, d.Get("alias").(string))
+
+	if err != nil && !IsServiceCallNotFound(err) {
+		return diag.FromErr(err)
+	}
+
+	// Built-in required actions can't be removed from a realm so stop requiring them instead
+	if err == nil {
+		requiredAction.Enabled = false
+		requiredAction.DefaultAction = false
+		err = updateRealmRequiredAction(ctx, toznySDK, realmName, *requiredAction)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	if d.Get("terms_text").(string) != "" {
+		err = makeIdentityServiceCall(ctx, toznySDK, http.MethodDelete, realmLocalizationMessagePath(realmName, d.Get("terms_locale").(string), termsTextMessageKey), nil, nil)
+		if err != nil && !IsServiceCallNotFound(err) {
+			return diag.FromErr(err)
+		}
+	}
+
+	d.SetId("")
+
+	return diags
+}
+
+// resourceRealmRequiredActionImport imports a required action using an import ID of the form `realm_id/alias`.
+func resourceRealmRequiredActionImport(ctx context.Context, d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+	realmID, alias, err := parseRealmRequiredActionID(d.Id())
+	if err != nil {
+		return nil, err
+	}
+
+	toznySDK, err := MakeToznySDK(d, m)
+	if err != nil {
+		return nil, err
+	}
+
+	realmName, exists, err := FindRealmNameByID(ctx, toznySDK, strconv.Itoa(realmID))
+	if err != nil {
+		return nil, err
+	}
+	if !exists {
+		return nil, fmt.Errorf("realm %d not found", realmID)
+	}
+
+	d.Set("realm_name", realmName)
+	d.Set("realm_id", realmID)
+	d.Set("alias", alias)
+	d.Set("terms_locale", "en")
+	d.Set("client_credentials_filepath", "")
+	d.Set("client_credentials_config", "")
+
+	return []*schema.ResourceData{d}, nil
+}
+
+// parseRealmRequiredActionID parses the ID of a required action, of the form `realm_id/alias`,
+// returning the realm ID, the alias and error (if any).
+func parseRealmRequiredActionID(id string) (int, string, error) {
+	parts := strings.SplitN(id, "/", 2)
+	if len(parts) != 2 || parts[1] == "" {
+		return 0, "", fmt.Errorf("invalid required action ID %q, expected realm_id/alias", id)
+	}
+
+	realmID, err := strconv.Atoi(parts[0])
+	if err != nil || realmID <= 0 {
+		return 0, "", fmt.Errorf("invalid required action ID %q, expected realm_id/alias", id)
+	}
+
+	return realmID, parts[1], nil
+}
+
+// validateRealmRequiredActionAlias rejects the alias of the required action to configure a one time password generator,
+// which tozny_realm_otp_policy manages with require_for_new_identities.
+func validateRealmRequiredActionAlias(value interface{}, key string) ([]string, []error) {
+	if value.(string) == configureOTPRequiredActionAlias {
+		return nil, []error{fmt.Errorf("%s can't be %q, use require_for_new_identities of tozny_realm_otp_policy instead", key, configureOTPRequiredActionAlias)}
+	}
+	return nil, nil
+}
@@ -16,6 +16,10 @@ func resourceRealmRole() *schema.Resource {
 		UpdateContext: resourceRealmRoleUpdate,
 		ReadContext:   resourceRealmRoleRead,
 		DeleteContext: resourceRealmRoleDelete,
+		CustomizeDiff: ForceNewUnlessRealmRenamed,
+		Importer: &schema.ResourceImporter{
+			StateContext: resourceRealmRoleImport,
+		},
 		Schema: map[string]*schema.Schema{
 			"client_credentials_filepath": {
 				Description:   "The filepath to Tozny client credentials for the Terraform provider to use when provisioning this realm provider.",
@@ -38,8 +42,8 @@ func resourceRealmRole() *schema.Resource {
 				Description: "The name of the Realm to provision the realm Role for.",
 				Type:        schema.TypeString,
 				Required:    true,
-				ForceNew:    true,
 			},
+			"realm_id": RealmIDSchema(),
 			"name": {
 				Description: "Human readable/reference-able name for the realm role.",
 				Type:        schema.TypeString,
@@ -143,6 +147,13 @@ func resourceRealmRoleUpdate(ctx context.Context, d *schema.ResourceData, m inte
 		return diag.FromErr(err)
 	}
 
+	// Only renames of the realm are updated in place, pointing the resource at a different realm replaces it
+	err = CheckRealmRenamed(ctx, toznySDK, d)
+
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
 	if d.HasChanges("description", "attribute") {
 		role := identityClient.Role{
 			ID:          d.Get("realm_role_id").(string),
@@ -180,6 +191,18 @@ func resourceRealmRoleRead(ctx context.Context, d *schema.ResourceData, m interf
 		return diag.FromErr(err)
 	}
 
+	// Look the realm up by ID so the resource can still be read after the realm is renamed
+	realmExists, err := ReadRealmIntoState(ctx, toznySDK, d)
+
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	if !realmExists {
+		d.SetId("")
+		return diags
+	}
+
 	realmRole, err := toznySDK.DescribeRealmRole(ctx, identityClient.DescribeRealmRoleRequest{
 		RealmName: strings.ToLower(d.Get("realm_name").(string)),
 		RoleID:    d.Get("realm_role_id").(string),
@@ -223,3 +246,19 @@ func resourceRealmRoleDelete(ctx context.Context, d *schema.ResourceData, m inte
 
 	return diags
 }
+
+// resourceRealmRoleImport imports a realm role using an import ID of the form `realm_name/realm_role_id`.
+func resourceRealmRoleImport(ctx context.Context, d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+	realmName, realmRoleID, err := ParseRealmScopedImportID(d.Id())
+	if err != nil {
+		return nil, err
+	}
+
+	d.SetId(realmRoleID)
+	d.Set("realm_name", realmName)
+	d.Set("realm_role_id", realmRoleID)
+	d.Set("client_credentials_filepath", "")
+	d.Set("client_credentials_config", "")
+
+	return []*schema.ResourceData{d}, nil
+}
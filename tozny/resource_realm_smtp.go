@@ -0,0 +1,395 @@
+package tozny
+
+import (
+	"context"
+	"crypto/tls"
+	"fmt"
+	"net"
+	"net/smtp"
+	"strconv"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/tozny/e3db-go/v2"
+)
+
+// realmEmailTemplateNames are the names of the realm emails whose subject and body can be overridden.
+var realmEmailTemplateNames = []string{
+	"email-verification",
+	"password-reset",
+	"execute-actions",
+	"email-update-confirmation",
+	"login-disabled",
+	"identity-provider-link",
+	"event-login-error",
+	"event-update-password",
+	"event-update-totp",
+	"event-remove-totp",
+}
+
+// resourceRealmSMTP returns the schema and methods for configuring email delivery for a Tozny Realm
+func resourceRealmSMTP() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceRealmSMTPCreateOrUpdate,
+		ReadContext:   resourceRealmSMTPRead,
+		UpdateContext: resourceRealmSMTPCreateOrUpdate,
+		DeleteContext: resourceRealmSMTPDelete,
+		CustomizeDiff: ForceNewUnlessRealmRenamed,
+		Importer: &schema.ResourceImporter{
+			StateContext: importRealmSettingsResource,
+		},
+		Schema: map[string]*schema.Schema{
+			"client_credentials_filepath": {
+				Description:   "The filepath to Tozny client credentials for the provider to use when provisioning this SMTP configuration.",
+				Type:          schema.TypeString,
+				Optional:      true,
+				Default:       "",
+				ForceNew:      true,
+				ConflictsWith: []string{"client_credentials_config"},
+			},
+			"client_credentials_config": {
+				Description:   "The Tozny account client configuration as a JSON string",
+				Type:          schema.TypeString,
+				Optional:      true,
+				Default:       "",
+				ForceNew:      true,
+				Sensitive:     true,
+				ConflictsWith: []string{"client_credentials_filepath"},
+			},
+			"realm_name": {
+				Description: "Name of the realm to configure email delivery for.",
+				Type:        schema.TypeString,
+				Required:    true,
+			},
+			"realm_id": RealmIDSchema(),
+			"host": {
+				Description: "Host name of the SMTP server.",
+				Type:        schema.TypeString,
+				Required:    true,
+			},
+			"port": {
+				Description:  "Port of the SMTP server.",
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      25,
+				ValidateFunc: validation.IsPortNumber,
+			},
+			"from": {
+				Description: "Email address realm emails are sent from.",
+				Type:        schema.TypeString,
+				Required:    true,
+			},
+			"from_display_name": {
+				Description: "Display name realm emails are sent from.",
+				Type:        schema.TypeString,
+				Optional:    true,
+				Default:     "",
+			},
+			"reply_to": {
+				Description: "Email address replies to realm emails are sent to.",
+				Type:        schema.TypeString,
+				Optional:    true,
+				Default:     "",
+			},
+			"envelope_from": {
+				Description: "Email address bounces of realm emails are sent to.",
+				Type:        schema.TypeString,
+				Optional:    true,
+				Default:     "",
+			},
+			"starttls": {
+				Description:   "Whether to upgrade the connection to the SMTP server using STARTTLS.",
+				Type:          schema.TypeBool,
+				Optional:      true,
+				Default:       false,
+				ConflictsWith: []string{"ssl"},
+			},
+			"ssl": {
+				Description:   "Whether to connect to the SMTP server over SSL/TLS.",
+				Type:          schema.TypeBool,
+				Optional:      true,
+				Default:       false,
+				ConflictsWith: []string{"starttls"},
+			},
+			"auth": {
+				Description: "Whether to authenticate with the SMTP server.",
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+			},
+			"username": {
+				Description: "Username to authenticate with the SMTP server when `auth` is enabled.",
+				Type:        schema.TypeString,
+				Optional:    true,
+				Default:     "",
+			},
+			"password": {
+				Description: "Password to authenticate with the SMTP server when `auth` is enabled.",
+				Type:        schema.TypeString,
+				Optional:    true,
+				Default:     "",
+				Sensitive:   true,
+			},
+			"test_connection": {
+				Description: "Whether to check that the SMTP server can be connected (and authenticated) to from where Terraform is run before applying the configuration.",
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+			},
+			"email_template": {
+				Description: "Overrides for the subject and body of emails sent by the realm.",
+				Type:        schema.TypeSet,
+				Optional:    true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"name": {
+							Description:  "Name of the email to override, e.g. `password-reset` or `execute-actions`.",
+							Type:         schema.TypeString,
+							Required:     true,
+							ValidateFunc: validation.StringInSlice(realmEmailTemplateNames, false),
+						},
+						"subject": {
+							Description: "Subject of the email.",
+							Type:        schema.TypeString,
+							Required:    true,
+						},
+						"text_body": {
+							Description: "Plain text body of the email.",
+							Type:        schema.TypeString,
+							Optional:    true,
+							Default:     "",
+						},
+						"html_body": {
+							Description: "HTML body of the email.",
+							Type:        schema.TypeString,
+							Optional:    true,
+							Default:     "",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func resourceRealmSMTPCreateOrUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	return createOrUpdateRealmSettingsResource(ctx, d, m, applyRealmSMTP, resourceRealmSMTPRead)
+}
+
+// applyRealmSMTP applies the configured SMTP server and email templates to the named realm after optionally
+// testing the connection to the server, returning error (if any).
+func applyRealmSMTP(ctx context.Context, toznySDK *e3db.ToznySDKV3, d *schema.ResourceData, realmName string) error {
+	if d.Get("test_connection").(bool) {
+		err := testSMTPConnection(ctx, smtpConnectionSettingsFromSchema(d))
+		if err != nil {
+			return fmt.Errorf("unable to connect to SMTP server %s:%d: %s", d.Get("host").(string), d.Get("port").(int), err)
+		}
+	}
+
+	smtpServer := map[string]string{
+		"host":            d.Get("host").(string),
+		"port":            strconv.Itoa(d.Get("port").(int)),
+		"from":            d.Get("from").(string),
+		"fromDisplayName": d.Get("from_display_name").(string),
+		"replyTo":         d.Get("reply_to").(string),
+		"envelopeFrom":    d.Get("envelope_from").(string),
+		"starttls":        strconv.FormatBool(d.Get("starttls").(bool)),
+		"ssl":             strconv.FormatBool(d.Get("ssl").(bool)),
+		"auth":            strconv.FormatBool(d.Get("auth").(bool)),
+		"user":            d.Get("username").(string),
+		"password":        d.Get("password").(string),
+	}
+
+	emailTemplates := map[string]realmEmailTemplate{}
+	for _, rawEmailTemplate := range d.Get("email_template").(*schema.Set).List() {
+		emailTemplate := rawEmailTemplate.(map[string]interface{})
+		emailTemplates[emailTemplate["name"].(string)] = realmEmailTemplate{
+			Subject:  emailTemplate["subject"].(string),
+			TextBody: emailTemplate["text_body"].(string),
+			HTMLBody: emailTemplate["html_body"].(string),
+		}
+	}
+
+	return updateRealmSettings(ctx, toznySDK, realmName, realmSettings{
+		SMTPServer:     &smtpServer,
+		EmailTemplates: &emailTemplates,
+	})
+}
+
+func resourceRealmSMTPRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	toznySDK, err := MakeToznySDK(d, m)
+
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	// Look the realm up by ID so the resource can still be read after the realm is renamed
+	realmExists, err := readRealmSettingsResourceRealm(ctx, toznySDK, d)
+
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	if !realmExists {
+		d.SetId("")
+		return diags
+	}
+
+	realmName := d.Get("realm_name").(string)
+
+	settings, err := describeRealmSettings(ctx, toznySDK, realmName)
+
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	var smtpServer map[string]string
+	if settings.SMTPServer != nil {
+		smtpServer = *settings.SMTPServer
+	}
+	// A realm whose SMTP server was removed outside of Terraform reads back empty values, planning to configure it again
+	port, _ := strconv.Atoi(smtpServer["port"])
+	starttls, _ := strconv.ParseBool(smtpServer["starttls"])
+	ssl, _ := strconv.ParseBool(smtpServer["ssl"])
+	auth, _ := strconv.ParseBool(smtpServer["auth"])
+
+	d.Set("host", smtpServer["host"])
+	d.Set("port", port)
+	d.Set("from", smtpServer["from"])
+	d.Set("from_display_name", smtpServer["fromDisplayName"])
+	d.Set("reply_to", smtpServer["replyTo"])
+	d.Set("envelope_from", smtpServer["envelopeFrom"])
+	d.Set("starttls", starttls)
+	d.Set("ssl", ssl)
+	d.Set("auth", auth)
+	d.Set("username", smtpServer["user"])
+	// The service never returns the SMTP password so the configured password is kept as is
+
+	emailTemplates := []interface{}{}
+	if settings.EmailTemplates != nil {
+		for name, emailTemplate := range *settings.EmailTemplates {
+			emailTemplates = append(emailTemplates, map[string]interface{}{
+				"name":      name,
+				"subject":   emailTemplate.Subject,
+				"text_body": emailTemplate.TextBody,
+				"html_body": emailTemplate.HTMLBody,
+			})
+		}
+	}
+	d.Set("email_template", emailTemplates)
+
+	return diags
+}
+
+func resourceRealmSMTPDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	toznySDK, err := MakeToznySDK(d, m)
+
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	// Empty settings remove the realm's SMTP server and email template overrides
+	noSMTPServer := map[string]string{}
+	noEmailTemplates := map[string]realmEmailTemplate{}
+
+	err = updateRealmSettings(ctx, toznySDK, d.Get("realm_name").(string), realmSettings{
+		SMTPServer:     &noSMTPServer,
+		EmailTemplates: &noEmailTemplates,
+	})
+
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId("")
+
+	return diags
+}
+
+// smtpConnectionTimeout bounds how long testing the connection to an SMTP server can take.
+const smtpConnectionTimeout = 30 * time.Second
+
+// smtpConnectionSettings wraps the settings used to connect to an SMTP server.
+type smtpConnectionSettings struct {
+	Host     string
+	Port     int
+	SSL      bool
+	StartTLS bool
+	Auth     bool
+	Username string
+	Password string
+}
+
+// smtpConnectionSettingsFromSchema returns the settings for connecting to the configured SMTP server.
+func smtpConnectionSettingsFromSchema(d *schema.ResourceData) smtpConnectionSettings {
+	return smtpConnectionSettings{
+		Host:     d.Get("host").(string),
+		Port:     d.Get("port").(int),
+		SSL:      d.Get("ssl").(bool),
+		StartTLS: d.Get("starttls").(bool),
+		Auth:     d.Get("auth").(bool),
+		Username: d.Get("username").(string),
+		Password: d.Get("password").(string),
+	}
+}
+
+// testSMTPConnection connects to an SMTP server, upgrading the connection and authenticating as
+// configured, within smtpConnectionTimeout or the deadline of ctx, returning error (if any).
+func testSMTPConnection(ctx context.Context, settings smtpConnectionSettings) error {
+	address := net.JoinHostPort(settings.Host, strconv.Itoa(settings.Port))
+	tlsConfig := &tls.Config{ServerName: settings.Host}
+
+	deadline := time.Now().Add(smtpConnectionTimeout)
+	if ctxDeadline, ok := ctx.Deadline(); ok && ctxDeadline.Before(deadline) {
+		deadline = ctxDeadline
+	}
+
+	dialer := net.Dialer{Timeout: smtpConnectionTimeout}
+	connection, err := dialer.DialContext(ctx, "tcp", address)
+	if err != nil {
+		return err
+	}
+
+	// Bound the SMTP conversation as well, as a server can accept connections and then never respond
+	err = connection.SetDeadline(deadline)
+	if err != nil {
+		connection.Close()
+		return err
+	}
+
+	if settings.SSL {
+		connection = tls.Client(connection, tlsConfig)
+	}
+
+	client, err := smtp.NewClient(connection, settings.Host)
+	if err != nil {
+		connection.Close()
+		return err
+	}
+	defer client.Close()
+
+	if settings.StartTLS {
+		if supported, _ := client.Extension("STARTTLS"); !supported {
+			return fmt.Errorf("server does not support STARTTLS")
+		}
+		err = client.StartTLS(tlsConfig)
+		if err != nil {
+			return err
+		}
+	}
+
+	if settings.Auth {
+		err = client.Auth(smtp.PlainAuth("", settings.Username, settings.Password, settings.Host))
+		if err != nil {
+			return err
+		}
+	}
+
+	return client.Quit()
+}
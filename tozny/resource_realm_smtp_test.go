@@ -0,0 +1,114 @@
+package tozny
+
+import (
+	"bufio"
+	"context"
+	"net"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+)
+
+// startFakeSMTPServer listens on a local port and serves each connection with handle,
+// returning the settings for connecting to it.
+func startFakeSMTPServer(t *testing.T, handle func(net.Conn)) smtpConnectionSettings {
+	t.Helper()
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("unable to listen: %s", err)
+	}
+	t.Cleanup(func() { listener.Close() })
+
+	go func() {
+		for {
+			connection, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			go func() {
+				defer connection.Close()
+				handle(connection)
+			}()
+		}
+	}()
+
+	address := listener.Addr().(*net.TCPAddr)
+	return smtpConnectionSettings{
+		Host: address.IP.String(),
+		Port: address.Port,
+	}
+}
+
+// serveSMTP answers a client with the minimal SMTP conversation needed to greet and quit.
+func serveSMTP(connection net.Conn) {
+	reader := bufio.NewReader(connection)
+	connection.Write([]byte("220 localhost ESMTP\r\n"))
+	for {
+		line, err := reader.ReadString('\n')
+		if err != nil {
+			return
+		}
+		command := strings.ToUpper(strings.Fields(line + " ")[0])
+		switch command {
+		case "EHLO", "HELO":
+			connection.Write([]byte("250 localhost\r\n"))
+		case "QUIT":
+			connection.Write([]byte("221 bye\r\n"))
+			return
+		default:
+			connection.Write([]byte("502 not implemented\r\n"))
+		}
+	}
+}
+
+func TestTestSMTPConnection(t *testing.T) {
+	settings := startFakeSMTPServer(t, serveSMTP)
+
+	err := testSMTPConnection(context.Background(), settings)
+	if err != nil {
+		t.Fatalf("expected connection to succeed, got %s", err)
+	}
+}
+
+func TestTestSMTPConnectionStartTLSUnsupported(t *testing.T) {
+	settings := startFakeSMTPServer(t, serveSMTP)
+	settings.StartTLS = true
+
+	err := testSMTPConnection(context.Background(), settings)
+	if err == nil {
+		t.Fatal("expected an error for a server without STARTTLS")
+	}
+}
+
+func TestTestSMTPConnectionUnresponsiveServer(t *testing.T) {
+	hold := make(chan struct{})
+	defer close(hold)
+	settings := startFakeSMTPServer(t, func(net.Conn) { <-hold })
+
+	ctx, cancel := context.WithTimeout(context.Background(), 500*time.Millisecond)
+	defer cancel()
+
+	start := time.Now()
+	err := testSMTPConnection(ctx, settings)
+	if err == nil {
+		t.Fatal("expected an error for a server that never greets")
+	}
+	if elapsed := time.Since(start); elapsed > 5*time.Second {
+		t.Fatalf("expected the context deadline to end the test, took %s", elapsed)
+	}
+}
+
+func TestTestSMTPConnectionRefused(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("unable to listen: %s", err)
+	}
+	port := listener.Addr().(*net.TCPAddr).Port
+	listener.Close()
+
+	err = testSMTPConnection(context.Background(), smtpConnectionSettings{Host: "127.0.0.1", Port: port})
+	if err == nil {
+		t.Fatal("expected an error connecting to a closed port on 127.0.0.1:" + strconv.Itoa(port))
+	}
+}
@@ -0,0 +1,328 @@
+package tozny
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io/ioutil"
+	"mime"
+	"net/http"
+	"net/url"
+	"path/filepath"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/customdiff"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/tozny/e3db-go/v2"
+)
+
+// realmBrandingAssets maps the name of each realm branding asset to the Terraform attribute for the local file of the asset.
+var realmBrandingAssets = map[string]string{
+	"logo":    "logo_filepath",
+	"favicon": "favicon_filepath",
+}
+
+// realmBrandingAsset wraps an image used to brand the pages of a realm.
+type realmBrandingAsset struct {
+	ContentType string `json:"content_type"`
+	Content     []byte `json:"content,omitempty"`
+}
+
+// resourceRealmTheme returns the schema and methods for configuring the themes and branding of a Tozny Realm
+func resourceRealmTheme() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceRealmThemeCreateOrUpdate,
+		ReadContext:   resourceRealmThemeRead,
+		UpdateContext: resourceRealmThemeCreateOrUpdate,
+		DeleteContext: resourceRealmThemeDelete,
+		CustomizeDiff: customdiff.All(resourceRealmThemeCustomizeDiff, ForceNewUnlessRealmRenamed),
+		Importer: &schema.ResourceImporter{
+			StateContext: importRealmSettingsResource,
+		},
+		Schema: map[string]*schema.Schema{
+			"client_credentials_filepath": {
+				Description:   "The filepath to Tozny client credentials for the provider to use when provisioning this theme.",
+				Type:          schema.TypeString,
+				Optional:      true,
+				Default:       "",
+				ForceNew:      true,
+				ConflictsWith: []string{"client_credentials_config"},
+			},
+			"client_credentials_config": {
+				Description:   "The Tozny account client configuration as a JSON string",
+				Type:          schema.TypeString,
+				Optional:      true,
+				Default:       "",
+				ForceNew:      true,
+				Sensitive:     true,
+				ConflictsWith: []string{"client_credentials_filepath"},
+			},
+			"realm_name": {
+				Description: "Name of the realm to theme.",
+				Type:        schema.TypeString,
+				Required:    true,
+			},
+			"realm_id": RealmIDSchema(),
+			"login_theme": {
+				Description: "Theme for the realm's login pages. Empty to use the service default.",
+				Type:        schema.TypeString,
+				Optional:    true,
+				Default:     "",
+			},
+			"account_theme": {
+				Description: "Theme for the realm's account management pages. Empty to use the service default.",
+				Type:        schema.TypeString,
+				Optional:    true,
+				Default:     "",
+			},
+			"email_theme": {
+				Description: "Theme for emails sent by the realm. Empty to use the service default.",
+				Type:        schema.TypeString,
+				Optional:    true,
+				Default:     "",
+			},
+			"display_name": {
+				Description: "Name of the realm displayed to identities.",
+				Type:        schema.TypeString,
+				Optional:    true,
+				Default:     "",
+			},
+			"display_name_html": {
+				Description: "HTML name of the realm displayed to identities on the login pages.",
+				Type:        schema.TypeString,
+				Optional:    true,
+				Default:     "",
+			},
+			"logo_filepath": {
+				Description: "Filepath to an image to upload as the realm's logo.",
+				Type:        schema.TypeString,
+				Optional:    true,
+				Default:     "",
+			},
+			"logo_hash": {
+				Description: "SHA-256 hash of the realm's logo, used to detect changes to the logo.",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+			"favicon_filepath": {
+				Description: "Filepath to an image to upload as the realm's favicon.",
+				Type:        schema.TypeString,
+				Optional:    true,
+				Default:     "",
+			},
+			"favicon_hash": {
+				Description: "SHA-256 hash of the realm's favicon, used to detect changes to the favicon.",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+		},
+	}
+}
+
+func resourceRealmThemeCreateOrUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	return createOrUpdateRealmSettingsResource(ctx, d, m, applyRealmTheme, resourceRealmThemeRead)
+}
+
+// applyRealmTheme applies the configured themes and display name to the named realm and uploads or removes
+// any branding assets that changed, returning error (if any).
+func applyRealmTheme(ctx context.Context, toznySDK *e3db.ToznySDKV3, d *schema.ResourceData, realmName string) error {
+	loginTheme := d.Get("login_theme").(string)
+	accountTheme := d.Get("account_theme").(string)
+	emailTheme := d.Get("email_theme").(string)
+	displayName := d.Get("display_name").(string)
+	displayNameHTML := d.Get("display_name_html").(string)
+
+	err := updateRealmSettings(ctx, toznySDK, realmName, realmSettings{
+		LoginTheme:      &loginTheme,
+		AccountTheme:    &accountTheme,
+		EmailTheme:      &emailTheme,
+		DisplayName:     &displayName,
+		DisplayNameHTML: &displayNameHTML,
+	})
+
+	if err != nil {
+		return err
+	}
+
+	for asset, attribute := range realmBrandingAssets {
+		if !d.IsNewResource() && !d.HasChanges(attribute, asset+"_hash") {
+			continue
+		}
+		assetFilepath := d.Get(attribute).(string)
+		if assetFilepath == "" {
+			err = deleteRealmBrandingAsset(ctx, toznySDK, realmName, asset)
+			if err != nil && !IsServiceCallNotFound(err) {
+				return err
+			}
+			continue
+		}
+		err = uploadRealmBrandingAsset(ctx, toznySDK, realmName, asset, assetFilepath)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func resourceRealmThemeRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	toznySDK, err := MakeToznySDK(d, m)
+
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	// Look the realm up by ID so the resource can still be read after the realm is renamed
+	realmExists, err := readRealmSettingsResourceRealm(ctx, toznySDK, d)
+
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	if !realmExists {
+		d.SetId("")
+		return diags
+	}
+
+	realmName := d.Get("realm_name").(string)
+
+	settings, err := describeRealmSettings(ctx, toznySDK, realmName)
+
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.Set("login_theme", stringSetting(settings.LoginTheme))
+	d.Set("account_theme", stringSetting(settings.AccountTheme))
+	d.Set("email_theme", stringSetting(settings.EmailTheme))
+	d.Set("display_name", stringSetting(settings.DisplayName))
+	d.Set("display_name_html", stringSetting(settings.DisplayNameHTML))
+
+	for asset := range realmBrandingAssets {
+		var brandingAsset realmBrandingAsset
+		err = makeIdentityServiceCall(ctx, toznySDK, http.MethodGet, realmBrandingAssetPath(realmName, asset), nil, &brandingAsset)
+		if err != nil && !IsServiceCallNotFound(err) {
+			return diag.FromErr(err)
+		}
+		// Hashed the same way as the local file so only changes to the asset's contents show up in plans
+		d.Set(asset+"_hash", realmBrandingAssetHash(brandingAsset.Content))
+	}
+
+	return diags
+}
+
+func resourceRealmThemeDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	toznySDK, err := MakeToznySDK(d, m)
+
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	realmName := d.Get("realm_name").(string)
+
+	// Empty themes and display names make the realm use the service's default themes and name
+	serviceDefault := ""
+	err = updateRealmSettings(ctx, toznySDK, realmName, realmSettings{
+		LoginTheme:      &serviceDefault,
+		AccountTheme:    &serviceDefault,
+		EmailTheme:      &serviceDefault,
+		DisplayName:     &serviceDefault,
+		DisplayNameHTML: &serviceDefault,
+	})
+
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	for asset := range realmBrandingAssets {
+		err = deleteRealmBrandingAsset(ctx, toznySDK, realmName, asset)
+		if err != nil && !IsServiceCallNotFound(err) {
+			return diag.FromErr(err)
+		}
+	}
+
+	d.SetId("")
+
+	return diags
+}
+
+// resourceRealmThemeCustomizeDiff plans the upload of any branding asset whose local file
+// content no longer matches the content hash of the asset uploaded to the realm.
+func resourceRealmThemeCustomizeDiff(ctx context.Context, d *schema.ResourceDiff, m interface{}) error {
+	for asset, attribute := range realmBrandingAssets {
+		// The file can only be read once its path is known, until then the content hash is unknown
+		if !d.NewValueKnown(attribute) {
+			err := d.SetNewComputed(asset + "_hash")
+			if err != nil {
+				return err
+			}
+			continue
+		}
+		assetFilepath := d.Get(attribute).(string)
+		if assetFilepath == "" {
+			if d.Get(asset+"_hash").(string) != "" {
+				err := d.SetNew(asset+"_hash", "")
+				if err != nil {
+					return err
+				}
+			}
+			continue
+		}
+		content, err := ioutil.ReadFile(assetFilepath)
+		if err != nil {
+			return fmt.Errorf("unable to read %s %q: %s", asset, assetFilepath, err)
+		}
+		contentHash := realmBrandingAssetHash(content)
+		if d.Get(asset+"_hash").(string) != contentHash {
+			err = d.SetNew(asset+"_hash", contentHash)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// realmBrandingAssetHash returns the hex encoded SHA-256 hash of the contents of a branding asset,
+// or "" for an asset without contents.
+func realmBrandingAssetHash(content []byte) string {
+	if len(content) == 0 {
+		return ""
+	}
+	hash := sha256.Sum256(content)
+	return hex.EncodeToString(hash[:])
+}
+
+// realmBrandingAssetPath returns the identity service path for the named branding asset of the named realm.
+func realmBrandingAssetPath(realmName string, asset string) string {
+	return fmt.Sprintf("/v1/identity/realm/%s/branding/%s", url.PathEscape(realmName), asset)
+}
+
+// uploadRealmBrandingAsset uploads the contents of the file at assetFilepath as the named branding asset of the named realm, returning error (if any).
+func uploadRealmBrandingAsset(ctx context.Context, toznySDK *e3db.ToznySDKV3, realmName string, asset string, assetFilepath string) error {
+	content, err := ioutil.ReadFile(assetFilepath)
+	if err != nil {
+		return err
+	}
+
+	contentType := mime.TypeByExtension(filepath.Ext(assetFilepath))
+	if contentType == "" {
+		contentType = http.DetectContentType(content)
+	}
+
+	return makeIdentityServiceCall(ctx, toznySDK, http.MethodPut, realmBrandingAssetPath(realmName, asset), realmBrandingAsset{
+		ContentType: contentType,
+		Content:     content,
+	}, nil)
+}
+
+// deleteRealmBrandingAsset removes the named branding asset from the named realm, returning error (if any).
+func deleteRealmBrandingAsset(ctx context.Context, toznySDK *e3db.ToznySDKV3, realmName string, asset string) error {
+	return makeIdentityServiceCall(ctx, toznySDK, http.MethodDelete, realmBrandingAssetPath(realmName, asset), nil, nil)
+}
@@ -0,0 +1,461 @@
+package tozny
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/tozny/e3db-go/v2"
+)
+
+// realmUserProfile wraps the schema of the attributes identities of a realm have.
+type realmUserProfile struct {
+	Attributes []realmUserProfileAttribute `json:"attributes"`
+	Groups     json.RawMessage             `json:"groups,omitempty"`
+}
+
+// realmUserProfileAttribute wraps an attribute of a realm's user profile.
+type realmUserProfileAttribute struct {
+	Name        string                                `json:"name"`
+	DisplayName string                                `json:"display_name,omitempty"`
+	Validations map[string]map[string]interface{}     `json:"validations,omitempty"`
+	Required    *realmUserProfileAttributeRequired    `json:"required,omitempty"`
+	Permissions *realmUserProfileAttributePermissions `json:"permissions,omitempty"`
+	Annotations map[string]interface{}                `json:"annotations,omitempty"`
+	Group       string                                `json:"group,omitempty"`
+	Selector    map[string]interface{}                `json:"selector,omitempty"`
+}
+
+// realmUserProfileAttributeRequired wraps the roles of identities required to provide an attribute.
+type realmUserProfileAttributeRequired struct {
+	Roles []string `json:"roles"`
+}
+
+// realmUserProfileAttributePermissions wraps the roles of identities allowed to view and edit an attribute.
+type realmUserProfileAttributePermissions struct {
+	View []string `json:"view"`
+	Edit []string `json:"edit"`
+}
+
+// realmUserProfileBuiltInAttributes are the attributes every realm's user profile has,
+// which are kept in the profile unless the resource declares them.
+var realmUserProfileBuiltInAttributes = []string{"username", "email", "firstName", "lastName"}
+
+// resourceRealmUserProfile returns the schema and methods for declaring the attributes of identities of a Tozny Realm
+func resourceRealmUserProfile() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceRealmUserProfileCreateOrUpdate,
+		ReadContext:   resourceRealmUserProfileRead,
+		UpdateContext: resourceRealmUserProfileCreateOrUpdate,
+		DeleteContext: resourceRealmUserProfileDelete,
+		CustomizeDiff: ForceNewUnlessRealmRenamed,
+		Importer: &schema.ResourceImporter{
+			StateContext: importRealmSettingsResource,
+		},
+		Schema: map[string]*schema.Schema{
+			"client_credentials_filepath": {
+				Description:   "The filepath to Tozny client credentials for the provider to use when provisioning this user profile.",
+				Type:          schema.TypeString,
+				Optional:      true,
+				Default:       "",
+				ForceNew:      true,
+				ConflictsWith: []string{"client_credentials_config"},
+			},
+			"client_credentials_config": {
+				Description:   "The Tozny account client configuration as a JSON string",
+				Type:          schema.TypeString,
+				Optional:      true,
+				Default:       "",
+				ForceNew:      true,
+				Sensitive:     true,
+				ConflictsWith: []string{"client_credentials_filepath"},
+			},
+			"realm_name": {
+				Description: "Name of the realm to declare the user profile for.",
+				Type:        schema.TypeString,
+				Required:    true,
+			},
+			"realm_id": RealmIDSchema(),
+			"attribute": {
+				Description: "Attributes identities of the realm have, in display order.",
+				Type:        schema.TypeList,
+				Optional:    true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"name": {
+							Description: "Name of the attribute.",
+							Type:        schema.TypeString,
+							Required:    true,
+						},
+						"display_name": {
+							Description: "Name of the attribute displayed to identities, which may reference a localized message as `${messageKey}`.",
+							Type:        schema.TypeString,
+							Optional:    true,
+							Default:     "",
+						},
+						"required_for_roles": {
+							Description: "Roles (`user` and/or `admin`) that must provide the attribute. Empty if the attribute is optional.",
+							Type:        schema.TypeSet,
+							Optional:    true,
+							Elem: &schema.Schema{
+								Type:         schema.TypeString,
+								ValidateFunc: validation.StringInSlice([]string{"user", "admin"}, false),
+							},
+						},
+						"view_permissions": {
+							Description: "Roles (`user` and/or `admin`) that can view the attribute.",
+							Type:        schema.TypeSet,
+							Optional:    true,
+							Elem: &schema.Schema{
+								Type:         schema.TypeString,
+								ValidateFunc: validation.StringInSlice([]string{"user", "admin"}, false),
+							},
+						},
+						"edit_permissions": {
+							Description: "Roles (`user` and/or `admin`) that can edit the attribute.",
+							Type:        schema.TypeSet,
+							Optional:    true,
+							Elem: &schema.Schema{
+								Type:         schema.TypeString,
+								ValidateFunc: validation.StringInSlice([]string{"user", "admin"}, false),
+							},
+						},
+						"length_validator": {
+							Description: "Bounds on the length of the attribute's value.",
+							Type:        schema.TypeList,
+							Optional:    true,
+							MaxItems:    1,
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"min": {
+										Description:  "Minimum length of the value.",
+										Type:         schema.TypeInt,
+										Optional:     true,
+										Default:      0,
+										ValidateFunc: validation.IntAtLeast(0),
+									},
+									"max": {
+										Description:  "Maximum length of the value. `0` for no maximum.",
+										Type:         schema.TypeInt,
+										Optional:     true,
+										Default:      0,
+										ValidateFunc: validation.IntAtLeast(0),
+									},
+								},
+							},
+						},
+						"pattern_validator": {
+							Description: "Regular expression the attribute's value must match.",
+							Type:        schema.TypeList,
+							Optional:    true,
+							MaxItems:    1,
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"pattern": {
+										Description:  "Regular expression the value must match.",
+										Type:         schema.TypeString,
+										Required:     true,
+										ValidateFunc: validation.StringIsValidRegExp,
+									},
+									"error_message": {
+										Description: "Message displayed when the value doesn't match, which may reference a localized message.",
+										Type:        schema.TypeString,
+										Optional:    true,
+										Default:     "",
+									},
+								},
+							},
+						},
+						"email_validator": {
+							Description: "Whether the attribute's value must be an email address.",
+							Type:        schema.TypeBool,
+							Optional:    true,
+							Default:     false,
+						},
+						"options_validator": {
+							Description: "Values the attribute is restricted to.",
+							Type:        schema.TypeList,
+							Optional:    true,
+							Elem: &schema.Schema{
+								Type: schema.TypeString,
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func resourceRealmUserProfileCreateOrUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	return createOrUpdateRealmSettingsResource(ctx, d, m, applyRealmUserProfile, resourceRealmUserProfileRead)
+}
+
+// applyRealmUserProfile merges the configured attributes into the user profile of the named realm, returning error (if any).
+func applyRealmUserProfile(ctx context.Context, toznySDK *e3db.ToznySDKV3, d *schema.ResourceData, realmName string) error {
+	// Start from the current profile so attribute groups and built-in
+	// attributes the resource doesn't declare are left in place
+	profile, err := describeRealmUserProfile(ctx, toznySDK, realmName)
+
+	if err != nil {
+		return err
+	}
+
+	declaredAttributes := map[string]bool{}
+	attributes := []realmUserProfileAttribute{}
+	for _, rawAttribute := range d.Get("attribute").([]interface{}) {
+		attribute := realmUserProfileAttributeFromSchema(rawAttribute.(map[string]interface{}))
+		if declaredAttributes[attribute.Name] {
+			return fmt.Errorf("attribute %q is declared more than once", attribute.Name)
+		}
+		declaredAttributes[attribute.Name] = true
+		attributes = append(attributes, attribute)
+	}
+
+	profile.Attributes = append(undeclaredBuiltInUserProfileAttributes(profile.Attributes, declaredAttributes), attributes...)
+
+	return updateRealmUserProfile(ctx, toznySDK, realmName, *profile)
+}
+
+func resourceRealmUserProfileRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	toznySDK, err := MakeToznySDK(d, m)
+
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	// Look the realm up by ID so the resource can still be read after the realm is renamed
+	realmExists, err := readRealmSettingsResourceRealm(ctx, toznySDK, d)
+
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	if !realmExists {
+		d.SetId("")
+		return diags
+	}
+
+	realmName := d.Get("realm_name").(string)
+
+	profile, err := describeRealmUserProfile(ctx, toznySDK, realmName)
+
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	// Built-in attributes are only managed by the resource once declared
+	declaredAttributes := map[string]bool{}
+	for _, rawAttribute := range d.Get("attribute").([]interface{}) {
+		declaredAttributes[rawAttribute.(map[string]interface{})["name"].(string)] = true
+	}
+
+	attributes := []interface{}{}
+	for _, attribute := range profile.Attributes {
+		if isRealmUserProfileBuiltInAttribute(attribute.Name) && !declaredAttributes[attribute.Name] {
+			continue
+		}
+		attributes = append(attributes, realmUserProfileAttributeToSchema(attribute))
+	}
+
+	if err := d.Set("attribute", attributes); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return diags
+}
+
+func resourceRealmUserProfileDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	toznySDK, err := MakeToznySDK(d, m)
+
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	realmName := d.Get("realm_name").(string)
+
+	profile, err := describeRealmUserProfile(ctx, toznySDK, realmName)
+
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	// Built-in attributes can't be removed from a realm's user profile so only custom attributes are removed
+	profile.Attributes = undeclaredBuiltInUserProfileAttributes(profile.Attributes, map[string]bool{})
+
+	err = updateRealmUserProfile(ctx, toznySDK, realmName, *profile)
+
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId("")
+
+	return diags
+}
+
+// realmUserProfileAttributeFromSchema builds a user profile attribute from an attribute block.
+func realmUserProfileAttributeFromSchema(rawAttribute map[string]interface{}) realmUserProfileAttribute {
+	attribute := realmUserProfileAttribute{
+		Name:        rawAttribute["name"].(string),
+		DisplayName: rawAttribute["display_name"].(string),
+		Validations: map[string]map[string]interface{}{},
+		Permissions: &realmUserProfileAttributePermissions{
+			View: SchemaToStringSlice(rawAttribute["view_permissions"].(*schema.Set).List()),
+			Edit: SchemaToStringSlice(rawAttribute["edit_permissions"].(*schema.Set).List()),
+		},
+	}
+
+	if roles := SchemaToStringSlice(rawAttribute["required_for_roles"].(*schema.Set).List()); len(roles) > 0 {
+		attribute.Required = &realmUserProfileAttributeRequired{
+			Roles: roles,
+		}
+	}
+
+	if lengthValidators := rawAttribute["length_validator"].([]interface{}); len(lengthValidators) > 0 && lengthValidators[0] != nil {
+		lengthValidator := lengthValidators[0].(map[string]interface{})
+		length := map[string]interface{}{
+			"min": lengthValidator["min"].(int),
+		}
+		if max := lengthValidator["max"].(int); max > 0 {
+			length["max"] = max
+		}
+		attribute.Validations["length"] = length
+	}
+	if patternValidators := rawAttribute["pattern_validator"].([]interface{}); len(patternValidators) > 0 && patternValidators[0] != nil {
+		patternValidator := patternValidators[0].(map[string]interface{})
+		pattern := map[string]interface{}{
+			"pattern": patternValidator["pattern"].(string),
+		}
+		if errorMessage := patternValidator["error_message"].(string); errorMessage != "" {
+			pattern["error-message"] = errorMessage
+		}
+		attribute.Validations["pattern"] = pattern
+	}
+	if rawAttribute["email_validator"].(bool) {
+		attribute.Validations["email"] = map[string]interface{}{}
+	}
+	if options := SchemaToStringSlice(rawAttribute["options_validator"].([]interface{})); len(options) > 0 {
+		attribute.Validations["options"] = map[string]interface{}{
+			"options": options,
+		}
+	}
+
+	return attribute
+}
+
+// realmUserProfileAttributeToSchema flattens a user profile attribute into an attribute block.
+func realmUserProfileAttributeToSchema(attribute realmUserProfileAttribute) map[string]interface{} {
+	rawAttribute := map[string]interface{}{
+		"name":               attribute.Name,
+		"display_name":       attribute.DisplayName,
+		"required_for_roles": []string{},
+		"view_permissions":   []string{},
+		"edit_permissions":   []string{},
+		"length_validator":   []interface{}{},
+		"pattern_validator":  []interface{}{},
+		"email_validator":    false,
+		"options_validator":  []string{},
+	}
+
+	if attribute.Required != nil {
+		rawAttribute["required_for_roles"] = attribute.Required.Roles
+	}
+	if attribute.Permissions != nil {
+		rawAttribute["view_permissions"] = attribute.Permissions.View
+		rawAttribute["edit_permissions"] = attribute.Permissions.Edit
+	}
+
+	if length, ok := attribute.Validations["length"]; ok {
+		rawAttribute["length_validator"] = []interface{}{
+			map[string]interface{}{
+				"min": userProfileValidationInt(length["min"]),
+				"max": userProfileValidationInt(length["max"]),
+			},
+		}
+	}
+	if pattern, ok := attribute.Validations["pattern"]; ok {
+		patternValidator := map[string]interface{}{
+			"pattern":       fmt.Sprint(pattern["pattern"]),
+			"error_message": "",
+		}
+		if errorMessage, ok := pattern["error-message"]; ok {
+			patternValidator["error_message"] = fmt.Sprint(errorMessage)
+		}
+		rawAttribute["pattern_validator"] = []interface{}{patternValidator}
+	}
+	if _, ok := attribute.Validations["email"]; ok {
+		rawAttribute["email_validator"] = true
+	}
+	if options, ok := attribute.Validations["options"]["options"].([]interface{}); ok {
+		rawAttribute["options_validator"] = options
+	}
+
+	return rawAttribute
+}
+
+// userProfileValidationInt converts a numeric user profile validation setting, which may
+// be a JSON number or a string, to an int, treating missing and invalid settings as 0.
+func userProfileValidationInt(setting interface{}) int {
+	switch value := setting.(type) {
+	case float64:
+		return int(value)
+	case string:
+		var number int
+		fmt.Sscanf(value, "%d", &number)
+		return number
+	}
+	return 0
+}
+
+// isRealmUserProfileBuiltInAttribute returns whether the named attribute is one every realm's user profile has.
+func isRealmUserProfileBuiltInAttribute(name string) bool {
+	for _, builtInAttribute := range realmUserProfileBuiltInAttributes {
+		if name == builtInAttribute {
+			return true
+		}
+	}
+	return false
+}
+
+// undeclaredBuiltInUserProfileAttributes returns the built-in attributes of a user profile that aren't declared.
+func undeclaredBuiltInUserProfileAttributes(attributes []realmUserProfileAttribute, declaredAttributes map[string]bool) []realmUserProfileAttribute {
+	builtInAttributes := []realmUserProfileAttribute{}
+	for _, attribute := range attributes {
+		if isRealmUserProfileBuiltInAttribute(attribute.Name) && !declaredAttributes[attribute.Name] {
+			builtInAttributes = append(builtInAttributes, attribute)
+		}
+	}
+	return builtInAttributes
+}
+
+// realmUserProfilePath returns the identity service path for the user profile of the named realm.
+func realmUserProfilePath(realmName string) string {
+	return fmt.Sprintf("/v1/identity/realm/%s/users/profile", url.PathEscape(realmName))
+}
+
+// describeRealmUserProfile fetches the user profile of the named realm, returning the profile and error (if any).
+func describeRealmUserProfile(ctx context.Context, toznySDK *e3db.ToznySDKV3, realmName string) (*realmUserProfile, error) {
+	var profile realmUserProfile
+
+	err := makeIdentityServiceCall(ctx, toznySDK, http.MethodGet, realmUserProfilePath(realmName), nil, &profile)
+	if err != nil {
+		return nil, err
+	}
+
+	return &profile, nil
+}
+
+// updateRealmUserProfile replaces the user profile of the named realm, returning error (if any).
+func updateRealmUserProfile(ctx context.Context, toznySDK *e3db.ToznySDKV3, realmName string, profile realmUserProfile) error {
+	return makeIdentityServiceCall(ctx, toznySDK, http.MethodPut, realmUserProfilePath(realmName), profile, nil)
+}
@@ -0,0 +1,169 @@
+package tozny
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/tozny/e3db-go/v2"
+)
+
+// resourceRealmWebAuthnPolicy returns the schema and methods for configuring the WebAuthn policy of a Tozny Realm
+func resourceRealmWebAuthnPolicy() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceRealmWebAuthnPolicyCreateOrUpdate,
+		ReadContext:   resourceRealmWebAuthnPolicyRead,
+		UpdateContext: resourceRealmWebAuthnPolicyCreateOrUpdate,
+		DeleteContext: resourceRealmWebAuthnPolicyDelete,
+		CustomizeDiff: ForceNewUnlessRealmRenamed,
+		Importer: &schema.ResourceImporter{
+			StateContext: importRealmSettingsResource,
+		},
+		Schema: map[string]*schema.Schema{
+			"client_credentials_filepath": {
+				Description:   "The filepath to Tozny client credentials for the provider to use when provisioning this WebAuthn policy.",
+				Type:          schema.TypeString,
+				Optional:      true,
+				Default:       "",
+				ForceNew:      true,
+				ConflictsWith: []string{"client_credentials_config"},
+			},
+			"client_credentials_config": {
+				Description:   "The Tozny account client configuration as a JSON string",
+				Type:          schema.TypeString,
+				Optional:      true,
+				Default:       "",
+				ForceNew:      true,
+				Sensitive:     true,
+				ConflictsWith: []string{"client_credentials_filepath"},
+			},
+			"realm_name": {
+				Description: "Name of the realm to apply the WebAuthn policy to.",
+				Type:        schema.TypeString,
+				Required:    true,
+			},
+			"realm_id": RealmIDSchema(),
+			"relying_party_name": {
+				Description: "Human readable name of the relying party shown to identities when registering an authenticator.",
+				Type:        schema.TypeString,
+				Required:    true,
+			},
+			"relying_party_id": {
+				Description: "Domain of the relying party. Defaults to the realm's domain when empty.",
+				Type:        schema.TypeString,
+				Optional:    true,
+				Default:     "",
+			},
+			"attestation_preference": {
+				Description:  "How authenticator attestation is conveyed to the realm. Valid values are `not specified`, `none`, `indirect` and `direct`.",
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      "not specified",
+				ValidateFunc: validation.StringInSlice([]string{"not specified", "none", "indirect", "direct"}, false),
+			},
+			"authenticator_attachment": {
+				Description:  "Which authenticators can be registered. Valid values are `not specified`, `platform` and `cross-platform`.",
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      "not specified",
+				ValidateFunc: validation.StringInSlice([]string{"not specified", "platform", "cross-platform"}, false),
+			},
+			"user_verification": {
+				Description:  "Whether authenticators must verify the identity, e.g. with a PIN or biometric. Valid values are `not specified`, `required`, `preferred` and `discouraged`.",
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      "not specified",
+				ValidateFunc: validation.StringInSlice([]string{"not specified", "required", "preferred", "discouraged"}, false),
+			},
+		},
+	}
+}
+
+func resourceRealmWebAuthnPolicyCreateOrUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	return createOrUpdateRealmSettingsResource(ctx, d, m, applyRealmWebAuthnPolicy, resourceRealmWebAuthnPolicyRead)
+}
+
+// applyRealmWebAuthnPolicy applies the configured WebAuthn policy to the named realm, returning error (if any).
+func applyRealmWebAuthnPolicy(ctx context.Context, toznySDK *e3db.ToznySDKV3, d *schema.ResourceData, realmName string) error {
+	relyingPartyName := d.Get("relying_party_name").(string)
+	relyingPartyID := d.Get("relying_party_id").(string)
+	attestationPreference := d.Get("attestation_preference").(string)
+	authenticatorAttachment := d.Get("authenticator_attachment").(string)
+	userVerification := d.Get("user_verification").(string)
+
+	return updateRealmSettings(ctx, toznySDK, realmName, realmSettings{
+		WebAuthnPolicyRPEntityName:                    &relyingPartyName,
+		WebAuthnPolicyRPID:                            &relyingPartyID,
+		WebAuthnPolicyAttestationConveyancePreference: &attestationPreference,
+		WebAuthnPolicyAuthenticatorAttachment:         &authenticatorAttachment,
+		WebAuthnPolicyUserVerificationRequirement:     &userVerification,
+	})
+}
+
+func resourceRealmWebAuthnPolicyRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	toznySDK, err := MakeToznySDK(d, m)
+
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	// Look the realm up by ID so the resource can still be read after the realm is renamed
+	realmExists, err := readRealmSettingsResourceRealm(ctx, toznySDK, d)
+
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	if !realmExists {
+		d.SetId("")
+		return diags
+	}
+
+	realmName := d.Get("realm_name").(string)
+
+	settings, err := describeRealmSettings(ctx, toznySDK, realmName)
+
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.Set("relying_party_name", stringSetting(settings.WebAuthnPolicyRPEntityName))
+	d.Set("relying_party_id", stringSetting(settings.WebAuthnPolicyRPID))
+	d.Set("attestation_preference", stringSetting(settings.WebAuthnPolicyAttestationConveyancePreference))
+	d.Set("authenticator_attachment", stringSetting(settings.WebAuthnPolicyAuthenticatorAttachment))
+	d.Set("user_verification", stringSetting(settings.WebAuthnPolicyUserVerificationRequirement))
+
+	return diags
+}
+
+func resourceRealmWebAuthnPolicyDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	toznySDK, err := MakeToznySDK(d, m)
+
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	// Unset the relying party ID so the service falls back to the realm's host, and let any authenticator register
+	notSpecified := "not specified"
+	noRelyingPartyID := ""
+
+	err = updateRealmSettings(ctx, toznySDK, d.Get("realm_name").(string), realmSettings{
+		WebAuthnPolicyRPID: &noRelyingPartyID,
+		WebAuthnPolicyAttestationConveyancePreference: &notSpecified,
+		WebAuthnPolicyAuthenticatorAttachment:         &notSpecified,
+		WebAuthnPolicyUserVerificationRequirement:     &notSpecified,
+	})
+
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId("")
+
+	return diags
+}
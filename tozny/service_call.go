@@ -0,0 +1,126 @@
+package tozny
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"strings"
+
+	e3dbClients "github.com/tozny/e3db-clients-go"
+	"github.com/tozny/e3db-go/v2"
+)
+
+// ServiceCallError wraps a non successful response from a Tozny service API.
+type ServiceCallError struct {
+	Method     string
+	URL        string
+	StatusCode int
+	Body       string
+}
+
+// Error implements the error interface for a ServiceCallError.
+func (e *ServiceCallError) Error() string {
+	return fmt.Sprintf("%s %s returned %d: %s", e.Method, e.URL, e.StatusCode, e.Body)
+}
+
+// IsServiceCallNotFound returns whether err is a ServiceCallError for a resource the service could not find.
+func IsServiceCallNotFound(err error) bool {
+	serviceCallError, ok := err.(*ServiceCallError)
+	return ok && serviceCallError.StatusCode == http.StatusNotFound
+}
+
+// makeAccountServiceCall makes a request to the Tozny account service authenticated with an
+// account service token for account level operations not currently wrapped by the Tozny SDK,
+// deserializing the response (if any) into result and returning error (if any).
+func makeAccountServiceCall(ctx context.Context, apiEndpoint string, accountServiceToken string, method string, path string, body interface{}, result interface{}) error {
+	var requestBody io.Reader
+
+	if body != nil {
+		bodyBytes, err := json.Marshal(body)
+		if err != nil {
+			return err
+		}
+		requestBody = bytes.NewReader(bodyBytes)
+	}
+
+	url := strings.TrimSuffix(apiEndpoint, "/") + path
+
+	request, err := http.NewRequestWithContext(ctx, method, url, requestBody)
+	if err != nil {
+		return err
+	}
+
+	request.Header.Set("Authorization", fmt.Sprintf("Bearer %s", accountServiceToken))
+	if body != nil {
+		request.Header.Set("Content-Type", "application/json")
+	}
+
+	response, err := http.DefaultClient.Do(request)
+	if err != nil {
+		return err
+	}
+
+	defer response.Body.Close()
+
+	responseBody, err := ioutil.ReadAll(response.Body)
+	if err != nil {
+		return err
+	}
+
+	if response.StatusCode < http.StatusOK || response.StatusCode >= http.StatusMultipleChoices {
+		return &ServiceCallError{
+			Method:     method,
+			URL:        url,
+			StatusCode: response.StatusCode,
+			Body:       string(responseBody),
+		}
+	}
+
+	if result == nil || len(responseBody) == 0 {
+		return nil
+	}
+
+	return json.Unmarshal(responseBody, result)
+}
+
+// makeIdentityServiceCall makes a TSV1 request to the Tozny identity service signed with the
+// keys of the client the Tozny SDK is configured with for realm level operations not currently
+// wrapped by the Tozny SDK, deserializing the response (if any) into result and returning error (if any).
+func makeIdentityServiceCall(ctx context.Context, toznySDK *e3db.ToznySDKV3, method string, path string, body interface{}, result interface{}) error {
+	var requestBody io.Reader
+
+	if body != nil {
+		bodyBytes, err := json.Marshal(body)
+		if err != nil {
+			return err
+		}
+		requestBody = bytes.NewReader(bodyBytes)
+	}
+
+	url := strings.TrimSuffix(toznySDK.APIEndpoint, "/") + path
+
+	request, err := http.NewRequestWithContext(ctx, method, url, requestBody)
+	if err != nil {
+		return err
+	}
+
+	if body != nil {
+		request.Header.Set("Content-Type", "application/json")
+	}
+
+	err = e3dbClients.MakeSignedServiceCall(ctx, http.DefaultClient, request, toznySDK.E3dbPDSClient.SigningKeys, toznySDK.E3dbPDSClient.ClientID, result)
+	if requestError, ok := err.(*e3dbClients.RequestError); ok {
+		return &ServiceCallError{
+			Method:     method,
+			URL:        url,
+			StatusCode: requestError.StatusCode,
+			Body:       requestError.Error(),
+		}
+	}
+
+	return err
+}
@@ -3,12 +3,16 @@ package tozny
 import (
 	"context"
 	"encoding/json"
+	"fmt"
 	"io/ioutil"
 	"os"
+	"strings"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	e3dbClients "github.com/tozny/e3db-clients-go"
 	"github.com/tozny/e3db-clients-go/identityClient"
+	"github.com/tozny/e3db-clients-go/pdsClient"
 	"github.com/tozny/e3db-go/v2"
 )
 
@@ -36,8 +40,13 @@ func MakeToznySession(ctx context.Context, d *schema.ResourceData, terraformProv
 // MakeToznySDK uses Terraform provider and resource configuration to create a Tozny SDK provider,
 // returning the SDK and error (if any).
 func MakeToznySDK(d *schema.ResourceData, terraformProviderConfig interface{}) (*e3db.ToznySDKV3, error) {
-	sdkCredentialsFilePath := d.Get("client_credentials_filepath").(string)
-	accountJSON := d.Get("client_credentials_config").(string)
+	return MakeToznySDKFromCredentials(d.Get("client_credentials_filepath").(string), d.Get("client_credentials_config").(string), terraformProviderConfig)
+}
+
+// MakeToznySDKFromCredentials creates a Tozny SDK from either a client credentials file or JSON
+// client configuration, falling back to the Terraform provider configuration if neither is specified,
+// returning the SDK and error (if any).
+func MakeToznySDKFromCredentials(sdkCredentialsFilePath string, accountJSON string, terraformProviderConfig interface{}) (*e3db.ToznySDKV3, error) {
 	configSourceSpecified := sdkCredentialsFilePath != "" || accountJSON != ""
 
 	toznySDK, err := terraformProviderConfig.(TerraformToznySDKResult).SDK, terraformProviderConfig.(TerraformToznySDKResult).Err
@@ -52,38 +61,7 @@ func MakeToznySDK(d *schema.ResourceData, terraformProviderConfig interface{}) (
 			if err != nil {
 				return toznySDK, err
 			}
-			toznySDK, err = e3db.NewToznySDKV3(e3db.ToznySDKConfig{
-				ClientConfig: e3dbClients.ClientConfig{
-					ClientID:  config.ClientID,
-					APIKey:    config.APIKeyID,
-					APISecret: config.APISecret,
-					Host:      config.APIBaseURL,
-					AuthNHost: config.APIBaseURL,
-					SigningKeys: e3dbClients.SigningKeys{
-						Public: e3dbClients.Key{
-							Type:     e3dbClients.DefaultSigningKeyType,
-							Material: config.PublicSigningKey,
-						},
-						Private: e3dbClients.Key{
-							Type:     e3dbClients.DefaultSigningKeyType,
-							Material: config.PrivateSigningKey,
-						},
-					},
-					EncryptionKeys: e3dbClients.EncryptionKeys{
-						Private: e3dbClients.Key{
-							Material: config.PrivateKey,
-							Type:     e3dbClients.DefaultEncryptionKeyType,
-						},
-						Public: e3dbClients.Key{
-							Material: config.PublicKey,
-							Type:     e3dbClients.DefaultEncryptionKeyType,
-						},
-					},
-				},
-				AccountUsername: config.AccountUsername,
-				AccountPassword: config.AccountPassword,
-				APIEndpoint:     config.APIBaseURL,
-			})
+			toznySDK, err = NewToznySDKFromJSONConfig(config)
 		} else if sdkCredentialsFilePath != "" {
 			toznySDK, err = e3db.GetSDKV3(sdkCredentialsFilePath)
 
@@ -92,7 +70,127 @@ func MakeToznySDK(d *schema.ResourceData, terraformProviderConfig interface{}) (
 			}
 		}
 	}
-	return toznySDK, nil
+	return toznySDK, err
+}
+
+// NewToznySDKFromJSONConfig creates a Tozny SDK from client credentials in the
+// JSON config format, returning the SDK and error (if any).
+func NewToznySDKFromJSONConfig(config e3db.ToznySDKJSONConfig) (*e3db.ToznySDKV3, error) {
+	return e3db.NewToznySDKV3(e3db.ToznySDKConfig{
+		ClientConfig: e3dbClients.ClientConfig{
+			ClientID:  config.ClientID,
+			APIKey:    config.APIKeyID,
+			APISecret: config.APISecret,
+			Host:      config.APIBaseURL,
+			AuthNHost: config.APIBaseURL,
+			SigningKeys: e3dbClients.SigningKeys{
+				Public: e3dbClients.Key{
+					Type:     e3dbClients.DefaultSigningKeyType,
+					Material: config.PublicSigningKey,
+				},
+				Private: e3dbClients.Key{
+					Type:     e3dbClients.DefaultSigningKeyType,
+					Material: config.PrivateSigningKey,
+				},
+			},
+			EncryptionKeys: e3dbClients.EncryptionKeys{
+				Private: e3dbClients.Key{
+					Material: config.PrivateKey,
+					Type:     e3dbClients.DefaultEncryptionKeyType,
+				},
+				Public: e3dbClients.Key{
+					Material: config.PublicKey,
+					Type:     e3dbClients.DefaultEncryptionKeyType,
+				},
+			},
+		},
+		AccountUsername: config.AccountUsername,
+		AccountPassword: config.AccountPassword,
+		APIEndpoint:     config.APIBaseURL,
+	})
+}
+
+// WriteToznyCredentialsRecord encrypts and writes serialized credentials to a TozStore record
+// written and owned by the client of the provided SDK, returning the record ID and error (if any).
+func WriteToznyCredentialsRecord(ctx context.Context, toznySDK *e3db.ToznySDKV3, recordType string, credentials string) (string, error) {
+	sdkClientID := toznySDK.E3dbPDSClient.ClientID
+
+	credentialsRecordToWrite := pdsClient.Record{
+		Data: map[string]string{"credentials": credentials},
+		Metadata: pdsClient.Meta{
+			Type:     recordType,
+			WriterID: sdkClientID,
+			UserID:   sdkClientID,
+		},
+	}
+
+	encryptedCredentialsRecordToWrite, err := toznySDK.EncryptRecord(ctx, credentialsRecordToWrite)
+
+	if err != nil {
+		return "", err
+	}
+
+	record, err := toznySDK.E3dbPDSClient.WriteRecord(ctx, encryptedCredentialsRecordToWrite)
+
+	if err != nil {
+		return "", err
+	}
+
+	return record.Metadata.RecordID, nil
+}
+
+// MakeToznyVaultSDK creates a Tozny SDK for the client that owns the TozStore record credentials are persisted to
+// from either a client credentials file or JSON client configuration, returning the SDK and error (if any).
+// Unlike MakeToznySDKFromCredentials it never falls back to the Terraform provider configuration.
+func MakeToznyVaultSDK(sdkCredentialsFilePath string, accountJSON string) (*e3db.ToznySDKV3, error) {
+	if sdkCredentialsFilePath == "" && accountJSON == "" {
+		return nil, fmt.Errorf("one of %q or %q must be set to persist credentials to TozStore", "credentials_vault_client_credentials_filepath", "credentials_vault_client_credentials_config")
+	}
+
+	vaultSDK, err := MakeToznySDKFromCredentials(sdkCredentialsFilePath, accountJSON, TerraformToznySDKResult{})
+
+	if err != nil {
+		return nil, err
+	}
+
+	if vaultSDK.E3dbPDSClient.ClientID == "" {
+		return nil, fmt.Errorf("vault client credentials must include a client ID to own the credentials record")
+	}
+
+	return vaultSDK, nil
+}
+
+// DeleteToznyCredentialsRecord deletes a TozStore record previously written with WriteToznyCredentialsRecord,
+// returning error (if any).
+func DeleteToznyCredentialsRecord(ctx context.Context, toznySDK *e3db.ToznySDKV3, recordID string) error {
+	return toznySDK.DeleteRecord(ctx, pdsClient.DeleteRecordRequest{
+		RecordID: recordID,
+	})
+}
+
+// ReadToznyCredentialsRecord reads and decrypts serialized credentials from a TozStore record
+// previously written with WriteToznyCredentialsRecord, returning the credentials, record type and error (if any).
+func ReadToznyCredentialsRecord(ctx context.Context, toznySDK *e3db.ToznySDKV3, recordID string) (string, string, error) {
+	batchRecords, err := toznySDK.BatchGetRecords(ctx, pdsClient.BatchGetRecordsRequest{
+		RecordIDs:   []string{recordID},
+		IncludeData: true,
+	})
+
+	if err != nil {
+		return "", "", err
+	}
+
+	if len(batchRecords.Records) == 0 {
+		return "", "", fmt.Errorf("unable to find credentials record %q", recordID)
+	}
+
+	record, err := toznySDK.DecryptRecord(ctx, batchRecords.Records[0])
+
+	if err != nil {
+		return "", "", err
+	}
+
+	return record.Data["credentials"], record.Metadata.Type, nil
 }
 
 // ToznyBrokerIdentityConfig wraps values for creating a Tozny Identity for brokering Realm activities
@@ -182,3 +280,138 @@ func SchemaToStringSlice(schemaList []interface{}) []string {
 	}
 	return groupList
 }
+
+// ParseRealmScopedImportID splits an import ID of the form `realm_name/id` used to import
+// resources that belong to a realm, returning the realm name, the resource ID and error (if any).
+func ParseRealmScopedImportID(importID string) (string, string, error) {
+	parts := strings.SplitN(importID, "/", 2)
+	if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+		return "", "", fmt.Errorf("invalid import ID %q, expected realm_name/id", importID)
+	}
+	return parts[0], parts[1], nil
+}
+
+// FindRealmNameByID looks up the current name of the realm with the given ID, which unlike the
+// name does not change when a realm is renamed, returning the name, whether the realm exists and error (if any).
+func FindRealmNameByID(ctx context.Context, toznySDK *e3db.ToznySDKV3, realmID string) (string, bool, error) {
+	listedRealms, err := toznySDK.ListRealms(ctx)
+	if err != nil {
+		return "", false, err
+	}
+	for _, realm := range listedRealms.Realms {
+		if fmt.Sprintf("%d", realm.ID) == realmID {
+			return realm.Name, true, nil
+		}
+	}
+	return "", false, nil
+}
+
+// RealmIDSchema returns the schema for the ID of the realm a resource belongs to, which unlike
+// the realm name stays the same when the realm is renamed.
+func RealmIDSchema() *schema.Schema {
+	return &schema.Schema{
+		Description: "Service defined unique identifier for the realm the resource belongs to, such as tozny_realm.<name>.realm_id. Looked up from realm_name when not set.",
+		Type:        schema.TypeInt,
+		Optional:    true,
+		Computed:    true,
+		ForceNew:    true,
+	}
+}
+
+// ReadRealmIntoState looks up the realm a resource belongs to by its `realm_id`, so the resource can still
+// be read after the realm is renamed, and updates `realm_name` if it was. Resources without a `realm_id`
+// yet have it looked up by `realm_name`. It returns whether the realm exists and error (if any).
+func ReadRealmIntoState(ctx context.Context, toznySDK *e3db.ToznySDKV3, d *schema.ResourceData) (bool, error) {
+	listedRealms, err := toznySDK.ListRealms(ctx)
+	if err != nil {
+		return false, err
+	}
+	realmID, realmName := d.Get("realm_id").(int), d.Get("realm_name").(string)
+	for _, realm := range listedRealms.Realms {
+		if realmID != 0 && fmt.Sprintf("%d", realm.ID) != fmt.Sprintf("%d", realmID) {
+			continue
+		}
+		if realmID == 0 && !strings.EqualFold(realm.Name, realmName) {
+			continue
+		}
+		d.Set("realm_id", realm.ID)
+		// Keep the configured casing of names that only differ in case
+		if !strings.EqualFold(realm.Name, realmName) {
+			d.Set("realm_name", realm.Name)
+		}
+		return true, nil
+	}
+	return false, nil
+}
+
+// ForceNewUnlessRealmRenamed plans the replacement of a resource that belongs to a realm when its
+// `realm_name` changes, unless its known `realm_id` stays the same, in which case the realm was renamed
+// and the resource is updated in place to use the new name. Updates must call CheckRealmRenamed so a
+// `realm_name` naming a different realm without `realm_id` being set fails rather than being applied in place.
+func ForceNewUnlessRealmRenamed(ctx context.Context, d *schema.ResourceDiff, m interface{}) error {
+	if d.Id() == "" || !d.HasChange("realm_name") {
+		return nil
+	}
+
+	if d.NewValueKnown("realm_id") && !d.HasChange("realm_id") && d.Get("realm_id").(int) != 0 {
+		return nil
+	}
+
+	return d.ForceNew("realm_name")
+}
+
+// CheckRealmRenamed verifies that a change of the `realm_name` of a resource being updated in place
+// follows a rename of the realm with its `realm_id`, returning error (if any).
+func CheckRealmRenamed(ctx context.Context, toznySDK *e3db.ToznySDKV3, d *schema.ResourceData) error {
+	if d.IsNewResource() || !d.HasChange("realm_name") {
+		return nil
+	}
+
+	realmID, realmName := d.Get("realm_id").(int), d.Get("realm_name").(string)
+	currentRealmName, exists, err := FindRealmNameByID(ctx, toznySDK, fmt.Sprintf("%d", realmID))
+	if err != nil {
+		return err
+	}
+
+	if !exists || !strings.EqualFold(currentRealmName, realmName) {
+		return fmt.Errorf("realm %q is not realm %d this resource belongs to, set realm_id to the ID of realm %q to replace the resource", realmName, realmID, realmName)
+	}
+
+	return nil
+}
+
+// UpdateRealmRenamed returns the update function of a resource whose only change applied in place is
+// following a rename of its realm, which checks the rename with CheckRealmRenamed before reading the
+// resource back with read.
+func UpdateRealmRenamed(read schema.ReadContextFunc) schema.UpdateContextFunc {
+	return func(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+		toznySDK, err := MakeToznySDK(d, m)
+
+		if err != nil {
+			return diag.FromErr(err)
+		}
+
+		err = CheckRealmRenamed(ctx, toznySDK, d)
+
+		if err != nil {
+			return diag.FromErr(err)
+		}
+
+		return read(ctx, d, m)
+	}
+}
+
+// CheckDeletionProtection returns an error diagnostic describing how to destroy the resource
+// if the deletion protection of the resource is enabled, or nil if the resource can be destroyed.
+func CheckDeletionProtection(d *schema.ResourceData, resourceType string, name string) diag.Diagnostics {
+	if !d.Get("deletion_protection").(bool) {
+		return nil
+	}
+	return diag.Diagnostics{
+		{
+			Severity: diag.Error,
+			Summary:  fmt.Sprintf("%s %q is protected from deletion", resourceType, name),
+			Detail:   "deletion_protection is enabled for this resource. Set deletion_protection = false and apply that change before destroying or replacing the resource.",
+		},
+	}
+}
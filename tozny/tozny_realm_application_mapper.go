@@ -2,6 +2,8 @@ package tozny
 
 import (
 	"context"
+	"fmt"
+	"strings"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
@@ -14,7 +16,12 @@ func resourceRealmApplicationMapper() *schema.Resource {
 	return &schema.Resource{
 		CreateContext: resourceRealmApplicationMapperCreate,
 		ReadContext:   resourceRealmApplicationMapperRead,
+		UpdateContext: UpdateRealmRenamed(resourceRealmApplicationMapperRead),
+		CustomizeDiff: ForceNewUnlessRealmRenamed,
 		DeleteContext: resourceRealmApplicationMapperDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: resourceRealmApplicationMapperImport,
+		},
 		Schema: map[string]*schema.Schema{
 			"client_credentials_filepath": {
 				Description:   "The filepath to Tozny client credentials for the Terraform provider to use when provisioning this realm provider.",
@@ -37,8 +44,8 @@ func resourceRealmApplicationMapper() *schema.Resource {
 				Description: "The name of the Realm to provision the Application Mapper in.",
 				Type:        schema.TypeString,
 				Required:    true,
-				ForceNew:    true,
 			},
+			"realm_id": RealmIDSchema(),
 			"application_id": {
 				Description: "ID of the Application the Mapper is associated with.",
 				Type:        schema.TypeString,
@@ -267,6 +274,18 @@ func resourceRealmApplicationMapperRead(ctx context.Context, d *schema.ResourceD
 		return diag.FromErr(err)
 	}
 
+	// Look the realm up by ID so the resource can still be read after the realm is renamed
+	realmExists, err := ReadRealmIntoState(ctx, toznySDK, d)
+
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	if !realmExists {
+		d.SetId("")
+		return diags
+	}
+
 	applicationMapper, err := toznySDK.DescribeRealmApplicationMapper(ctx, identityClient.DescribeRealmApplicationMapperRequest{
 		RealmName:           d.Get("realm_name").(string),
 		ApplicationID:       d.Get("application_id").(string),
@@ -321,3 +340,26 @@ func resourceRealmApplicationMapperDelete(ctx context.Context, d *schema.Resourc
 
 	return diags
 }
+
+// resourceRealmApplicationMapperImport imports an application mapper using an import ID of the form
+// `realm_name/application_id/application_mapper_id`.
+func resourceRealmApplicationMapperImport(ctx context.Context, d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+	realmName, applicationScopedID, err := ParseRealmScopedImportID(d.Id())
+	if err != nil {
+		return nil, err
+	}
+
+	parts := strings.SplitN(applicationScopedID, "/", 2)
+	if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+		return nil, fmt.Errorf("invalid import ID %q, expected realm_name/application_id/application_mapper_id", d.Id())
+	}
+
+	d.SetId(parts[1])
+	d.Set("realm_name", realmName)
+	d.Set("application_id", parts[0])
+	d.Set("application_mapper_id", parts[1])
+	d.Set("client_credentials_filepath", "")
+	d.Set("client_credentials_config", "")
+
+	return []*schema.ResourceData{d}, nil
+}